@@ -0,0 +1,337 @@
+package go_obs
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AlertState is the state of an alert.
+type AlertState int
+
+const (
+	AlertResolved AlertState = iota
+	AlertFiring
+)
+
+func (s AlertState) String() string {
+	switch s {
+	case AlertResolved:
+		return "resolved"
+	case AlertFiring:
+		return "firing"
+	}
+	return "AlertState(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Alert is emitted when an alert rule starts firing or is resolved.
+type Alert struct {
+	// Name of the rule.
+	Rule string
+	// Whether the alert started firing or was resolved.
+	State AlertState
+	// Value which triggered the change.
+	Value float64
+	// Time the alert started firing.
+	Since time.Time
+	// Time of the change.
+	Time time.Time
+}
+
+// AlertInput is passed to alert rules when they are evaluated.
+type AlertInput struct {
+	// Time of the evaluation.
+	Now time.Time
+	// Most recent sample, if HasSample is true. HasSample is false if no
+	// sample has been recorded, or if newer samples should have arrived
+	// since (eg. StreamStatus events stopped because OBS went away).
+	Latest    StreamSample
+	HasSample bool
+	// Collector the samples come from, for rules which look at history.
+	Collector *HealthCollector
+	// Time the last Heartbeat event was received, or the time the alert
+	// engine was created if none has been received.
+	LastHeartbeat time.Time
+}
+
+// AlertRule describes a condition on stream health. The rule fires once its
+// value has been past Threshold for For, and resolves once the value has
+// been back past Clear for ResolveFor. Setting Clear apart from Threshold
+// gives hysteresis, so values hovering around the threshold don't flap.
+type AlertRule struct {
+	Name string
+	// Function Value returns the measured value. If ok is false the rule
+	// does not apply (eg. a stream rule while not streaming), and a firing
+	// alert is resolved.
+	Value func(in AlertInput) (v float64, ok bool)
+	// Whether the rule fires when the value is above the threshold, rather
+	// than below it.
+	Above      bool
+	Threshold  float64
+	Clear      float64
+	For        time.Duration
+	ResolveFor time.Duration
+}
+
+// Function StrainRule fires when the stream strain (percentage of dropped
+// frames) is above threshold for the given duration, and resolves when it
+// falls below half the threshold.
+func StrainRule(threshold float64, d time.Duration) AlertRule {
+	return AlertRule{
+		Name: "stream-strain",
+		Value: func(in AlertInput) (float64, bool) {
+			return in.Latest.Strain, in.HasSample && in.Latest.Streaming
+		},
+		Above:     true,
+		Threshold: threshold,
+		Clear:     threshold / 2,
+		For:       d,
+	}
+}
+
+// Function BitrateRule fires when the stream bitrate is below floor (in
+// kbit/s) for the given duration, and resolves when it rises 20% above the
+// floor.
+func BitrateRule(floor int, d time.Duration) AlertRule {
+	return AlertRule{
+		Name: "stream-bitrate",
+		Value: func(in AlertInput) (float64, bool) {
+			return float64(in.Latest.KbitsPerSec), in.HasSample && in.Latest.Streaming
+		},
+		Threshold: float64(floor),
+		Clear:     float64(floor) * 1.2,
+		For:       d,
+	}
+}
+
+// Function RenderMissedRule fires when more than perMinute frames per minute
+// are missed due to rendering lag over the given window, and resolves when
+// the rate falls below half of that.
+func RenderMissedRule(perMinute float64, window time.Duration) AlertRule {
+	return AlertRule{
+		Name: "render-missed-frames",
+		Value: func(in AlertInput) (float64, bool) {
+			stats := in.Collector.Stats(window)
+			return stats.RenderMissedFrames.PerMinute, stats.Samples >= 2
+		},
+		Above:     true,
+		Threshold: perMinute,
+		Clear:     perMinute / 2,
+	}
+}
+
+// Function FreeDiskRule fires when the free recording disk space is below
+// the given number of megabytes, and resolves when it rises 10% above it.
+func FreeDiskRule(megabytes float64) AlertRule {
+	return AlertRule{
+		Name: "free-disk-space",
+		Value: func(in AlertInput) (float64, bool) {
+			return in.Latest.FreeDiskSpace, in.HasSample
+		},
+		Threshold: megabytes,
+		Clear:     megabytes * 1.1,
+	}
+}
+
+// Function HeartbeatRule fires when no Heartbeat event has been received for
+// the given timeout, and resolves when one arrives. Heartbeats must be
+// enabled with SetHeartbeat.
+func HeartbeatRule(timeout time.Duration) AlertRule {
+	return AlertRule{
+		Name: "heartbeat-lost",
+		Value: func(in AlertInput) (float64, bool) {
+			return in.Now.Sub(in.LastHeartbeat).Seconds(), true
+		},
+		Above:     true,
+		Threshold: timeout.Seconds(),
+		Clear:     timeout.Seconds(),
+	}
+}
+
+// The interval at which rules are evaluated between samples, so that
+// durations and heartbeat timeouts are noticed.
+const alertInterval = time.Second
+
+// AlertEngine evaluates alert rules against the samples of a health
+// collector, and emits alerts when they fire or resolve.
+type AlertEngine struct {
+	h             *HealthCollector
+	mx            sync.Mutex
+	evalMx        sync.Mutex
+	rules         []*alertRuleState
+	lastHeartbeat time.Time
+	onAlert       map[int]func(Alert)
+	nextId        int
+	remove        []func()
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+type alertRuleState struct {
+	rule    AlertRule
+	firing  bool
+	since   time.Time
+	pending time.Time
+}
+
+// Function NewAlertEngine creates an alert engine which evaluates the given
+// rules whenever the collector records a sample, and once a second.
+func NewAlertEngine(c *Client, h *HealthCollector, rules ...AlertRule) *AlertEngine {
+	e := &AlertEngine{
+		h:             h,
+		lastHeartbeat: time.Now(),
+		onAlert:       make(map[int]func(Alert)),
+		done:          make(chan struct{}),
+	}
+	for _, r := range rules {
+		e.rules = append(e.rules, &alertRuleState{rule: r})
+	}
+	e.remove = []func(){
+		c.addListener("Heartbeat", func(any) {
+			e.mx.Lock()
+			e.lastHeartbeat = time.Now()
+			e.mx.Unlock()
+			e.evaluate(time.Now())
+		}),
+		h.OnSample(func(StreamSample) {
+			e.evaluate(time.Now())
+		}),
+	}
+	go func() {
+		ticker := time.NewTicker(alertInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-e.done:
+				return
+			case now := <-ticker.C:
+				e.evaluate(now)
+			}
+		}
+	}()
+	return e
+}
+
+// Function Close stops evaluating rules.
+func (e *AlertEngine) Close() {
+	e.closeOnce.Do(func() {
+		for _, r := range e.remove {
+			r()
+		}
+		close(e.done)
+	})
+}
+
+// Function OnAlert registers fn to be called whenever an alert fires or
+// resolves. The callback may run on the client's read loop, so it must not
+// block on requests. The returned function unregisters the callback.
+func (e *AlertEngine) OnAlert(fn func(Alert)) func() {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	id := e.nextId
+	e.nextId++
+	e.onAlert[id] = fn
+	return func() {
+		e.mx.Lock()
+		defer e.mx.Unlock()
+		delete(e.onAlert, id)
+	}
+}
+
+// Function Firing returns the names of the rules which are currently
+// firing.
+func (e *AlertEngine) Firing() []string {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	var out []string
+	for _, r := range e.rules {
+		if r.firing {
+			out = append(out, r.rule.Name)
+		}
+	}
+	return out
+}
+
+func (e *AlertEngine) evaluate(now time.Time) {
+	// Evaluations run one at a time, and deliver their alerts before the
+	// next one starts, so that alerts arrive in the order they happen.
+	e.evalMx.Lock()
+	defer e.evalMx.Unlock()
+	latest, ok := e.h.Latest()
+	if ok && e.h.stale(latest, now) {
+		ok = false
+	}
+	e.mx.Lock()
+	in := AlertInput{
+		Now:           now,
+		Latest:        latest,
+		HasSample:     ok,
+		Collector:     e.h,
+		LastHeartbeat: e.lastHeartbeat,
+	}
+	var alerts []Alert
+	for _, r := range e.rules {
+		if a, ok := r.evaluate(in); ok {
+			alerts = append(alerts, a)
+		}
+	}
+	fns := make([]func(Alert), 0, len(e.onAlert))
+	for _, fn := range e.onAlert {
+		fns = append(fns, fn)
+	}
+	e.mx.Unlock()
+
+	for _, a := range alerts {
+		for _, fn := range fns {
+			fn(a)
+		}
+	}
+}
+
+// Function evaluate updates the state of a rule, and returns an alert if it
+// started firing or was resolved.
+func (r *alertRuleState) evaluate(in AlertInput) (Alert, bool) {
+	v, ok := r.rule.Value(in)
+	alert := Alert{Rule: r.rule.Name, Value: v, Time: in.Now}
+
+	var past bool
+	var hold time.Duration
+	switch {
+	case !ok:
+		r.pending = time.Time{}
+		if !r.firing {
+			return alert, false
+		}
+		past = true
+	case !r.firing && r.rule.Above:
+		past, hold = v > r.rule.Threshold, r.rule.For
+	case !r.firing:
+		past, hold = v < r.rule.Threshold, r.rule.For
+	case r.rule.Above:
+		past, hold = v < r.rule.Clear, r.rule.ResolveFor
+	default:
+		past, hold = v > r.rule.Clear, r.rule.ResolveFor
+	}
+
+	if !past {
+		r.pending = time.Time{}
+		return alert, false
+	}
+	if r.pending.IsZero() {
+		r.pending = in.Now
+	}
+	if ok && in.Now.Sub(r.pending) < hold {
+		return alert, false
+	}
+
+	r.pending = time.Time{}
+	r.firing = !r.firing
+	if r.firing {
+		r.since = in.Now
+		alert.State = AlertFiring
+	} else {
+		alert.State = AlertResolved
+	}
+	alert.Since = r.since
+	return alert, true
+}
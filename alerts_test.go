@@ -0,0 +1,152 @@
+package go_obs_test
+
+import (
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function newAlertEngine returns an alert engine for the given rules, and
+// a function which sends a stream status event with the given strain.
+func newAlertEngine(t *testing.T, rules ...obs.AlertRule) (*obs.AlertEngine, chan obs.Alert, func(float64)) {
+	f := newFakeOBS(t)
+	c := f.connect()
+	h := obs.NewHealthCollector(c, 4, 0)
+	t.Cleanup(h.Close)
+	e := obs.NewAlertEngine(c, h, rules...)
+	t.Cleanup(e.Close)
+	alerts := make(chan obs.Alert, 16)
+	e.OnAlert(func(a obs.Alert) {
+		alerts <- a
+	})
+	return e, alerts, func(strain float64) {
+		f.event("StreamStatus", map[string]any{"streaming": true, "strain": strain})
+	}
+}
+
+func nextAlert(t *testing.T, alerts chan obs.Alert) obs.Alert {
+	t.Helper()
+	select {
+	case a := <-alerts:
+		return a
+	case <-time.After(2 * time.Second):
+		t.Fatal("no alert")
+		return obs.Alert{}
+	}
+}
+
+func TestAlertHysteresis(t *testing.T) {
+	e, alerts, send := newAlertEngine(t, obs.StrainRule(10, 0))
+
+	// Values between Clear (5) and Threshold (10) neither fire nor resolve.
+	for _, strain := range []float64{8, 12, 8, 9, 4, 8, 12} {
+		send(strain)
+	}
+	want := []struct {
+		state obs.AlertState
+		value float64
+	}{
+		{obs.AlertFiring, 12},
+		{obs.AlertResolved, 4},
+		{obs.AlertFiring, 12},
+	}
+	for _, w := range want {
+		a := nextAlert(t, alerts)
+		if a.Rule != "stream-strain" || a.State != w.state || a.Value != w.value {
+			t.Errorf("got %+v, want %v at %v", a, w.state, w.value)
+		}
+	}
+	if firing := e.Firing(); len(firing) != 1 || firing[0] != "stream-strain" {
+		t.Errorf("firing: %v", firing)
+	}
+	select {
+	case a := <-alerts:
+		t.Errorf("unexpected alert: %+v", a)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAlertHold(t *testing.T) {
+	hold := 200 * time.Millisecond
+	_, alerts, send := newAlertEngine(t, obs.StrainRule(10, hold))
+
+	start := time.Now()
+	send(12)
+	time.Sleep(hold / 4)
+	send(12)
+	select {
+	case a := <-alerts:
+		t.Fatalf("fired before the hold: %+v", a)
+	case <-time.After(hold):
+	}
+	send(12)
+	a := nextAlert(t, alerts)
+	if a.State != obs.AlertFiring || a.Time.Sub(start) < hold {
+		t.Errorf("got %+v after %v", a, a.Time.Sub(start))
+	}
+	if a.Since != a.Time {
+		t.Errorf("since: %v, time: %v", a.Since, a.Time)
+	}
+
+	// Dropping below the threshold restarts the hold.
+	_, alerts, send = newAlertEngine(t, obs.StrainRule(10, hold))
+	send(12)
+	time.Sleep(hold * 3 / 4)
+	send(8)
+	time.Sleep(hold / 2)
+	send(12)
+	select {
+	case a := <-alerts:
+		t.Errorf("hold not restarted: %+v", a)
+	case <-time.After(hold / 2):
+	}
+}
+
+func TestAlertStaleSample(t *testing.T) {
+	_, alerts, send := newAlertEngine(t, obs.StrainRule(10, 0))
+
+	send(12)
+	if a := nextAlert(t, alerts); a.State != obs.AlertFiring {
+		t.Fatalf("got %+v", a)
+	}
+	// Without further StreamStatus events, the last sample goes stale and
+	// the alert resolves.
+	start := time.Now()
+	select {
+	case a := <-alerts:
+		if a.State != obs.AlertResolved || time.Since(start) < 4*time.Second {
+			t.Errorf("got %+v after %v", a, time.Since(start))
+		}
+	case <-time.After(8 * time.Second):
+		t.Fatal("alert not resolved")
+	}
+}
+
+func TestAlertOrder(t *testing.T) {
+	_, alerts, send := newAlertEngine(t, obs.StrainRule(10, 0))
+
+	// Each sample flips the alert, while the ticker evaluates the rule
+	// concurrently. Fires and resolves must alternate.
+	const n = 200
+	go func() {
+		for i := 0; i < n; i++ {
+			if i%2 == 0 {
+				send(12)
+			} else {
+				send(4)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+	for i := 0; i < n; i++ {
+		a := nextAlert(t, alerts)
+		want := obs.AlertFiring
+		if i%2 == 1 {
+			want = obs.AlertResolved
+		}
+		if a.State != want {
+			t.Fatalf("alert %d: got %+v", i, a)
+		}
+	}
+}
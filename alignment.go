@@ -0,0 +1,123 @@
+package go_obs
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// Alignment describes the point on a scene item (or its bounding box) that
+// the item is manipulated from. It is the sum of 1=Left or 2=Right, and
+// 4=Top or 8=Bottom; omitting a flag centers on that axis.
+type Alignment int
+
+const (
+	AlignLeft   Alignment = 1
+	AlignRight  Alignment = 2
+	AlignTop    Alignment = 4
+	AlignBottom Alignment = 8
+
+	AlignCenter       Alignment = 0
+	AlignCenterLeft             = AlignLeft
+	AlignCenterRight            = AlignRight
+	AlignTopCenter              = AlignTop
+	AlignTopLeft                = AlignTop | AlignLeft
+	AlignTopRight               = AlignTop | AlignRight
+	AlignBottomCenter           = AlignBottom
+	AlignBottomLeft             = AlignBottom | AlignLeft
+	AlignBottomRight            = AlignBottom | AlignRight
+)
+
+// Function AlignmentFromAnchor returns the alignment nearest to the given
+// normalized anchor point, where (0, 0) is the top left corner and (1, 1)
+// is the bottom right corner.
+func AlignmentFromAnchor(x, y float64) Alignment {
+	var a Alignment
+	switch {
+	case x < 0.25:
+		a |= AlignLeft
+	case x > 0.75:
+		a |= AlignRight
+	}
+	switch {
+	case y < 0.25:
+		a |= AlignTop
+	case y > 0.75:
+		a |= AlignBottom
+	}
+	return a
+}
+
+// Function Valid returns whether the alignment is a valid combination of
+// flags.
+func (a Alignment) Valid() bool {
+	if a < 0 || a > AlignBottomRight {
+		return false
+	}
+	if a&AlignLeft != 0 && a&AlignRight != 0 {
+		return false
+	}
+	if a&AlignTop != 0 && a&AlignBottom != 0 {
+		return false
+	}
+	return true
+}
+
+// Function Anchor returns the normalized anchor point of the alignment,
+// where (0, 0) is the top left corner and (1, 1) is the bottom right corner.
+func (a Alignment) Anchor() (x, y float64) {
+	x, y = 0.5, 0.5
+	if a&AlignLeft != 0 {
+		x = 0
+	} else if a&AlignRight != 0 {
+		x = 1
+	}
+	if a&AlignTop != 0 {
+		y = 0
+	} else if a&AlignBottom != 0 {
+		y = 1
+	}
+	return x, y
+}
+
+// Function String returns a human readable name for the alignment, such as
+// "top-left" or "center".
+func (a Alignment) String() string {
+	if !a.Valid() {
+		return "Alignment(" + strconv.Itoa(int(a)) + ")"
+	}
+
+	var v, h string
+	if a&AlignTop != 0 {
+		v = "top"
+	} else if a&AlignBottom != 0 {
+		v = "bottom"
+	}
+	if a&AlignLeft != 0 {
+		h = "left"
+	} else if a&AlignRight != 0 {
+		h = "right"
+	}
+
+	switch {
+	case v == "" && h == "":
+		return "center"
+	case v == "":
+		return h
+	case h == "":
+		return v
+	default:
+		return v + "-" + h
+	}
+}
+
+// Function UnmarshalJSON decodes an alignment. Some responses encode the
+// alignment as a floating point number, so those are accepted as well.
+func (a *Alignment) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*a = Alignment(math.Round(f))
+	return nil
+}
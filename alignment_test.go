@@ -0,0 +1,47 @@
+package go_obs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestAlignmentString(t *testing.T) {
+	cases := map[obs.Alignment]string{
+		obs.AlignCenter:      "center",
+		obs.AlignTopLeft:     "top-left",
+		obs.AlignBottomRight: "bottom-right",
+		obs.AlignCenterLeft:  "left",
+		obs.AlignTopCenter:   "top",
+		obs.Alignment(3):     "Alignment(3)",
+	}
+	for a, want := range cases {
+		if got := a.String(); got != want {
+			t.Errorf("%d: got %q, want %q", int(a), got, want)
+		}
+	}
+}
+
+func TestAlignmentAnchor(t *testing.T) {
+	for a := obs.Alignment(0); a <= obs.AlignBottomRight; a++ {
+		if !a.Valid() {
+			continue
+		}
+		x, y := a.Anchor()
+		if got := obs.AlignmentFromAnchor(x, y); got != a {
+			t.Errorf("%s: round trip gave %s", a, got)
+		}
+	}
+}
+
+func TestAlignmentUnmarshal(t *testing.T) {
+	item := obs.SceneItem{}
+	err := json.Unmarshal([]byte(`{"alignment": 5.0}`), &item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Alignment != obs.AlignTopLeft {
+		t.Errorf("got %s, want top-left", item.Alignment)
+	}
+}
@@ -0,0 +1,74 @@
+package go_obs
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// BatchRequest is a request to run within ExecuteBatch, together with its
+// parameters.
+type BatchRequest struct {
+	RequestType string
+	// Parameters of the request, as a struct or map which marshals to a JSON
+	// object (eg. SetSceneItemPropertiesRequest).
+	Params any
+}
+
+func (r BatchRequest) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any)
+	if r.Params != nil {
+		if err := remarshal(r.Params, &m); err != nil {
+			return nil, err
+		}
+	}
+	m["request-type"] = r.RequestType
+	delete(m, "message-id")
+	return json.Marshal(m)
+}
+
+// Function Batch runs a list of requests with ExecuteBatch. Unlike
+// ExecuteBatch, each request carries its parameters. If any request fails,
+// an error naming it is returned along with the response.
+func (c *Client) Batch(requests []BatchRequest, abortOnFail bool) (*ExecuteBatchResponse, error) {
+	uuid := uuid.NewString()
+	errch := make(chan error)
+	defer close(errch)
+	req := struct {
+		reqData
+		Requests    []BatchRequest `json:"requests"`
+		AbortOnFail bool           `json:"abortOnFail"`
+	}{
+		reqData: reqData{
+			MessageId:   uuid,
+			RequestType: "ExecuteBatch",
+		},
+		Requests:    requests,
+		AbortOnFail: abortOnFail,
+	}
+
+	jdata, err := json.Marshal(&req)
+	if err != nil {
+		return nil, err
+	}
+	recvch := c.send(jdata, uuid, errch)
+	defer close(recvch)
+	select {
+	case val := <-recvch:
+		res := &ExecuteBatchResponse{}
+		err = json.Unmarshal(val, res)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range res.Results {
+			if v.Status == "error" && i < len(requests) {
+				return res, errors.New("batch request " + strconv.Itoa(i) + " (" + requests[i].RequestType + "): " + v.Error)
+			}
+		}
+		return res, nil
+	case err := <-errch:
+		return nil, err
+	}
+}
@@ -0,0 +1,106 @@
+package go_obs_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function fakeBatch answers ExecuteBatch like OBS does, running the
+// requests in order. Requests of the given type fail.
+func fakeBatch(f *fakeOBS, failing string) {
+	f.handle("ExecuteBatch", func(req map[string]any) (map[string]any, error) {
+		results := []any{}
+		for i, r := range req["requests"].([]any) {
+			r := r.(map[string]any)
+			result := map[string]any{"message-id": strings.Repeat("x", i+1), "status": "ok"}
+			if r["request-type"] == failing {
+				result["status"] = "error"
+				result["error"] = "failed"
+			}
+			results = append(results, result)
+			if result["status"] == "error" && req["abortOnFail"] == true {
+				break
+			}
+		}
+		return map[string]any{"results": results}, nil
+	})
+}
+
+var batchRequests = []obs.BatchRequest{
+	{RequestType: "SetCurrentScene", Params: obs.SetCurrentSceneRequest{SceneName: "BRB"}},
+	{RequestType: "SetMute", Params: map[string]any{"source": "Mic", "mute": true}},
+	{RequestType: "StopStreaming"},
+}
+
+func TestBatch(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeBatch(f, "")
+	c := f.connect()
+
+	res, err := c.Batch(batchRequests, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 3 {
+		t.Fatalf("results: %+v", res.Results)
+	}
+	// Results are in the order of the requests.
+	for i, r := range res.Results {
+		if r.Status != "ok" || r.MessageId != strings.Repeat("x", i+1) {
+			t.Errorf("result %d: %+v", i, r)
+		}
+	}
+
+	sent := f.sent("ExecuteBatch")[0]["requests"].([]any)
+	scene := sent[0].(map[string]any)
+	if scene["request-type"] != "SetCurrentScene" || scene["scene-name"] != "BRB" {
+		t.Errorf("request 0: %v", scene)
+	}
+	if _, ok := scene["message-id"]; ok {
+		t.Errorf("request 0 has a message ID: %v", scene)
+	}
+	if mute := sent[1].(map[string]any); mute["request-type"] != "SetMute" || mute["mute"] != true {
+		t.Errorf("request 1: %v", mute)
+	}
+}
+
+func TestBatchFailure(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeBatch(f, "SetMute")
+	c := f.connect()
+
+	// Without abortOnFail, the requests after the failing one still run.
+	res, err := c.Batch(batchRequests, false)
+	if err == nil || !strings.Contains(err.Error(), "batch request 1 (SetMute): failed") {
+		t.Errorf("got %v", err)
+	}
+	if res == nil || len(res.Results) != 3 || res.Results[1].Status != "error" || res.Results[2].Status != "ok" {
+		t.Fatalf("results: %+v", res)
+	}
+
+	res, err = c.Batch(batchRequests, true)
+	if err == nil || !strings.Contains(err.Error(), "batch request 1 (SetMute)") {
+		t.Errorf("got %v", err)
+	}
+	if res == nil || len(res.Results) != 2 {
+		t.Fatalf("results: %+v", res)
+	}
+	if sent := f.sent("ExecuteBatch"); sent[1]["abortOnFail"] != true {
+		t.Errorf("abortOnFail not sent: %v", sent[1])
+	}
+}
+
+func TestBatchRequestFails(t *testing.T) {
+	f := newFakeOBS(t)
+	f.handle("ExecuteBatch", func(map[string]any) (map[string]any, error) {
+		return nil, errors.New("invalid request")
+	})
+	c := f.connect()
+
+	if res, err := c.Batch(batchRequests, false); err == nil || res != nil {
+		t.Errorf("got %+v, %v", res, err)
+	}
+}
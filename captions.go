@@ -0,0 +1,321 @@
+package go_obs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Caption is a timed caption, as read from an SRT or WebVTT file.
+type Caption struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// Function ParseTimecode parses a timecode in the `HH:MM:SS.mmm` format used
+// by obs-websocket, or the `HH:MM:SS,mmm` and `MM:SS.mmm` formats used by
+// SRT and WebVTT.
+func ParseTimecode(s string) (time.Duration, error) {
+	s = strings.Replace(strings.TrimSpace(s), ",", ".", 1)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.New("invalid timecode: " + s)
+	}
+	var hours int
+	if len(parts) == 3 {
+		v, err := strconv.Atoi(parts[0])
+		if err != nil || v < 0 {
+			return 0, errors.New("invalid timecode: " + s)
+		}
+		hours, parts = v, parts[1:]
+	}
+	minutes, err := strconv.Atoi(parts[0])
+	if err != nil || minutes < 0 || minutes >= 60 {
+		return 0, errors.New("invalid timecode: " + s)
+	}
+	seconds, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || seconds < 0 || seconds >= 60 {
+		return 0, errors.New("invalid timecode: " + s)
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	return d + time.Duration(seconds*1000+0.5)*time.Millisecond, nil
+}
+
+// Function ParseSubtitles reads captions from an SRT or WebVTT file. WebVTT
+// is detected by its `WEBVTT` header. Formatting tags are kept as is.
+func ParseSubtitles(r io.Reader) ([]Caption, error) {
+	var out []Caption
+	var cur *Caption
+	var text []string
+	flush := func() {
+		if cur != nil && len(text) > 0 {
+			cur.Text = strings.Join(text, "\n")
+			out = append(out, *cur)
+		}
+		cur, text = nil, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	skip := false
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			s = strings.TrimPrefix(s, "\ufeff")
+			if strings.HasPrefix(s, "WEBVTT") {
+				skip = true
+				continue
+			}
+		}
+		if strings.TrimSpace(s) == "" {
+			flush()
+			skip = false
+			continue
+		}
+		if skip {
+			// WebVTT header, NOTE, STYLE and REGION blocks.
+			continue
+		}
+		if cur == nil {
+			if strings.HasPrefix(s, "NOTE") || s == "STYLE" || s == "REGION" {
+				skip = true
+				continue
+			}
+			i := strings.Index(s, "-->")
+			if i < 0 {
+				// Cue number or identifier.
+				continue
+			}
+			start, err := ParseTimecode(s[:i])
+			if err != nil {
+				return nil, errors.New("line " + strconv.Itoa(line) + ": " + err.Error())
+			}
+			// WebVTT cue settings follow the end time.
+			end, err := ParseTimecode(strings.Fields(s[i+3:] + " ")[0])
+			if err != nil {
+				return nil, errors.New("line " + strconv.Itoa(line) + ": " + err.Error())
+			}
+			cur = &Caption{Start: start, End: end}
+			continue
+		}
+		text = append(text, s)
+	}
+	flush()
+	return out, scanner.Err()
+}
+
+// Function SegmentCaption splits text into captions of at most maxLines lines
+// of at most maxLength characters, breaking between words. Words longer than
+// a line are split.
+func SegmentCaption(text string, maxLength, maxLines int) []string {
+	if maxLength <= 0 {
+		maxLength = 32
+	}
+	if maxLines <= 0 {
+		maxLines = 2
+	}
+
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		w := []rune(word)
+		for len(w) > maxLength {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lines = append(lines, string(w[:maxLength]))
+			w = w[maxLength:]
+		}
+		if len(line) > 0 && len(line)+1+len(w) > maxLength {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, w...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+
+	var out []string
+	for i := 0; i < len(lines); i += maxLines {
+		end := i + maxLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+		out = append(out, strings.Join(lines[i:end], "\n"))
+	}
+	return out
+}
+
+// CaptionFeeder sends captions to the stream with SendCaptions, split into
+// broadcast-friendly lengths and rate limited. It follows the stream
+// timecode from StreamStatus events to play timed captions in sync.
+type CaptionFeeder struct {
+	// Maximum characters per caption line. Defaults to 32, as in CEA-608.
+	MaxLength int
+	// Maximum lines per caption. Defaults to 2.
+	MaxLines int
+	// Minimum time between captions. Defaults to 1 second.
+	MinInterval time.Duration
+
+	c        *Client
+	mx       sync.Mutex
+	timecode time.Duration
+	received time.Time
+	lastSent time.Time
+	remove   func()
+}
+
+// Function NewCaptionFeeder creates a caption feeder for the given client.
+func NewCaptionFeeder(c *Client) *CaptionFeeder {
+	f := &CaptionFeeder{
+		MaxLength:   32,
+		MaxLines:    2,
+		MinInterval: time.Second,
+		c:           c,
+	}
+	f.remove = c.addListener("StreamStatus", func(event any) {
+		e := event.(*StreamStatusEvent)
+		if tc, err := ParseTimecode(e.StreamTimecode); err == nil {
+			f.mx.Lock()
+			f.timecode, f.received = tc, time.Now()
+			f.mx.Unlock()
+		}
+	})
+	return f
+}
+
+// Function Close stops the feeder from following the stream timecode.
+func (f *CaptionFeeder) Close() {
+	f.remove()
+}
+
+// Function StreamTime returns the current stream timecode, estimated from
+// the last StreamStatus event, or requested with GetStreamingStatus if none
+// has been received. It fails if OBS is not streaming.
+func (f *CaptionFeeder) StreamTime() (time.Duration, error) {
+	f.mx.Lock()
+	tc, received := f.timecode, f.received
+	f.mx.Unlock()
+	if !received.IsZero() && time.Since(received) < streamStatusTimeout {
+		return tc + time.Since(received), nil
+	}
+
+	res, err := f.c.GetStreamingStatus()
+	if err != nil {
+		return 0, err
+	}
+	if !res.Streaming {
+		return 0, errors.New("not streaming")
+	}
+	tc, err = ParseTimecode(res.StreamTimecode)
+	if err != nil {
+		return 0, err
+	}
+	f.mx.Lock()
+	f.timecode, f.received = tc, time.Now()
+	f.mx.Unlock()
+	return tc, nil
+}
+
+// Function Send splits text into captions and sends them, waiting at least
+// MinInterval between each.
+func (f *CaptionFeeder) Send(ctx context.Context, text string) error {
+	for _, s := range SegmentCaption(text, f.MaxLength, f.MaxLines) {
+		if err := f.send(ctx, s, f.MinInterval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *CaptionFeeder) send(ctx context.Context, text string, interval time.Duration) error {
+	f.mx.Lock()
+	wait := time.Until(f.lastSent.Add(interval))
+	f.mx.Unlock()
+	if err := sleepContext(ctx, wait); err != nil {
+		return err
+	}
+	if _, err := f.c.SendCaptions(text); err != nil {
+		return err
+	}
+	f.mx.Lock()
+	f.lastSent = time.Now()
+	f.mx.Unlock()
+	return nil
+}
+
+// Function FeedLines reads lines of text from r until it ends or ctx is
+// done, and sends each as captions.
+func (f *CaptionFeeder) FeedLines(ctx context.Context, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := f.Send(ctx, scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Function Play sends timed captions in sync with the stream. Caption times
+// are relative to the stream timecode given by offset; pass the result of
+// StreamTime to start them now. Captions which are already over are skipped.
+// Long captions are split and spread over their duration, but never faster
+// than MinInterval.
+func (f *CaptionFeeder) Play(ctx context.Context, captions []Caption, offset time.Duration) error {
+	for _, c := range captions {
+		now, err := f.StreamTime()
+		if err != nil {
+			return err
+		}
+		start, end := offset+c.Start, offset+c.End
+		if end <= now {
+			continue
+		}
+		if err := sleepContext(ctx, start-now); err != nil {
+			return err
+		}
+
+		segments := SegmentCaption(c.Text, f.MaxLength, f.MaxLines)
+		if len(segments) == 0 {
+			continue
+		}
+		spread := (c.End - c.Start) / time.Duration(len(segments))
+		if spread < f.MinInterval {
+			spread = f.MinInterval
+		}
+		for i, s := range segments {
+			interval := f.MinInterval
+			if i > 0 {
+				interval = spread
+			}
+			if err := f.send(ctx, s, interval); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,184 @@
+package go_obs_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestParseSubtitles(t *testing.T) {
+	want := []obs.Caption{
+		{Start: 1500 * time.Millisecond, End: 4 * time.Second, Text: "Hello there."},
+		{Start: time.Hour + 2*time.Minute + 3*time.Second + 40*time.Millisecond, End: time.Hour + 2*time.Minute + 5*time.Second, Text: "Two\nlines"},
+	}
+
+	srt := "1\r\n00:00:01,500 --> 00:00:04,000\r\nHello there.\r\n\r\n2\r\n01:02:03,040 --> 01:02:05,000\r\nTwo\r\nlines\r\n"
+	got, err := obs.ParseSubtitles(strings.NewReader(srt))
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("srt: got %+v (%v)", got, err)
+	}
+
+	vtt := "WEBVTT - Example\n\nNOTE a comment\nspanning lines\n\n00:01.500 --> 00:04.000 align:start\nHello there.\n\nid-2\n01:02:03.040 --> 01:02:05.000\nTwo\nlines\n"
+	got, err = obs.ParseSubtitles(strings.NewReader(vtt))
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("vtt: got %+v (%v)", got, err)
+	}
+
+	if _, err := obs.ParseSubtitles(strings.NewReader("1\n00:00:xx --> 00:00:01\nText\n")); err == nil {
+		t.Error("expected error for invalid timecode")
+	}
+}
+
+func TestSegmentCaption(t *testing.T) {
+	got := obs.SegmentCaption("The quick brown fox jumps over the lazy dog", 10, 2)
+	want := []string{"The quick\nbrown fox", "jumps over\nthe lazy", "dog"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got = obs.SegmentCaption("supercalifragilistic", 8, 1)
+	want = []string{"supercal", "ifragili", "stic"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := obs.SegmentCaption("  ", 32, 2); len(got) != 0 {
+		t.Errorf("got %q for blank text", got)
+	}
+}
+
+type sentCaption struct {
+	text string
+	at   time.Time
+}
+
+// Function fakeCaptions records the captions sent to f, with the time each
+// was received.
+func fakeCaptions(f *fakeOBS) func() []sentCaption {
+	var mx sync.Mutex
+	var sent []sentCaption
+	f.handle("SendCaptions", func(req map[string]any) (map[string]any, error) {
+		mx.Lock()
+		defer mx.Unlock()
+		sent = append(sent, sentCaption{req["text"].(string), time.Now()})
+		return nil, nil
+	})
+	return func() []sentCaption {
+		mx.Lock()
+		defer mx.Unlock()
+		return append([]sentCaption(nil), sent...)
+	}
+}
+
+// Function checkCaptions checks the texts of the sent captions, and that
+// they were at least interval apart.
+func checkCaptions(t *testing.T, sent []sentCaption, interval time.Duration, want ...string) {
+	t.Helper()
+	var texts []string
+	for i, s := range sent {
+		texts = append(texts, s.text)
+		if i > 0 && s.at.Sub(sent[i-1].at) < interval {
+			t.Errorf("caption %q sent %v after the previous one", s.text, s.at.Sub(sent[i-1].at))
+		}
+	}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+}
+
+func TestCaptionFeederSend(t *testing.T) {
+	f := newFakeOBS(t)
+	sent := fakeCaptions(f)
+	feeder := obs.NewCaptionFeeder(f.connect())
+	defer feeder.Close()
+	feeder.MaxLength, feeder.MaxLines = 10, 1
+	feeder.MinInterval = 50 * time.Millisecond
+
+	if err := feeder.Send(context.Background(), "The quick brown fox"); err != nil {
+		t.Fatal(err)
+	}
+	if err := feeder.FeedLines(context.Background(), strings.NewReader("jumps\nover\n")); err != nil {
+		t.Fatal(err)
+	}
+	checkCaptions(t, sent(), feeder.MinInterval, "The quick", "brown fox", "jumps", "over")
+
+	// Nothing is sent once the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := feeder.Send(ctx, "lazy dog"); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v", err)
+	}
+	if n := len(sent()); n != 4 {
+		t.Errorf("%d captions sent", n)
+	}
+}
+
+func TestCaptionFeederStreamTime(t *testing.T) {
+	f := newFakeOBS(t)
+	f.reply("GetStreamingStatus", map[string]any{"streaming": true, "stream-timecode": "00:01:00.000"})
+	feeder := obs.NewCaptionFeeder(f.connect())
+	defer feeder.Close()
+
+	// Without StreamStatus events, the timecode is requested once and then
+	// estimated.
+	for i := 0; i < 2; i++ {
+		tc, err := feeder.StreamTime()
+		if err != nil || tc < time.Minute || tc > time.Minute+time.Second {
+			t.Errorf("got %v (%v)", tc, err)
+		}
+	}
+	if n := len(f.sent("GetStreamingStatus")); n != 1 {
+		t.Errorf("GetStreamingStatus sent %d times", n)
+	}
+
+	f.event("StreamStatus", map[string]any{"streaming": true, "stream-timecode": "00:02:00.000"})
+	waitFor(t, "stream timecode", func() bool {
+		tc, err := feeder.StreamTime()
+		return err == nil && tc >= 2*time.Minute
+	})
+
+	f = newFakeOBS(t)
+	f.reply("GetStreamingStatus", map[string]any{"streaming": false})
+	feeder = obs.NewCaptionFeeder(f.connect())
+	defer feeder.Close()
+	if _, err := feeder.StreamTime(); err == nil {
+		t.Error("expected error while not streaming")
+	}
+}
+
+func TestCaptionFeederPlay(t *testing.T) {
+	f := newFakeOBS(t)
+	sent := fakeCaptions(f)
+	feeder := obs.NewCaptionFeeder(f.connect())
+	defer feeder.Close()
+	feeder.MaxLength, feeder.MaxLines = 5, 1
+	feeder.MinInterval = 80 * time.Millisecond
+
+	start := time.Now()
+	f.event("StreamStatus", map[string]any{"streaming": true, "stream-timecode": "00:00:10.000"})
+	waitFor(t, "stream timecode", func() bool {
+		tc, err := feeder.StreamTime()
+		return err == nil && tc >= 10*time.Second
+	})
+
+	err := feeder.Play(context.Background(), []obs.Caption{
+		{Start: 0, End: 5 * time.Second, Text: "Over"},
+		{Start: 9 * time.Second, End: 11 * time.Second, Text: "Now"},
+		// Four segments in 200ms are spread at MinInterval instead.
+		{Start: 10200 * time.Millisecond, End: 10400 * time.Millisecond, Text: "one two three four"},
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := sent()
+	checkCaptions(t, got, feeder.MinInterval, "Now", "one", "two", "three", "four")
+	if len(got) > 1 && got[1].at.Sub(start) < 200*time.Millisecond {
+		t.Errorf("caption sent %v after 10s, want 10.2s", got[1].at.Sub(start))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -19,6 +20,10 @@ type Client struct {
 	errMap        map[string]chan error
 	recvMap       map[string]chan []byte
 	eventHandlers map[string]func(any)
+	listeners     map[string][]*listener
+	imageFormats  []string
+	sent          map[string]time.Time
+	requestStats  RequestStats
 	mx            sync.Mutex
 	stop          chan struct{}
 }
@@ -50,15 +55,17 @@ func (c *Client) Connect(address string) (bool, chan error, error) {
 	c.url = address
 	c.errMap = make(map[string]chan error)
 	c.recvMap = make(map[string]chan []byte)
+	c.sent = make(map[string]time.Time)
 	c.stop = make(chan struct{})
+	c.imageFormats = nil
 
 	conn, _, err := websocket.DefaultDialer.Dial("ws://"+c.url, nil)
 	if err != nil {
 		return false, nil, err
 	}
-	errch := c.poll()
 	c.conn = conn
 	c.connected = true
+	errch := c.poll()
 	res, err := c.GetAuthRequired()
 	if err != nil {
 		conn.Close()
@@ -79,17 +86,112 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// Function Connected returns whether the Client is connected to OBS.
+func (c *Client) Connected() bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.connected
+}
+
+// RequestStats holds the number and latency of the requests sent by a
+// Client.
+type RequestStats struct {
+	// Number of requests which received a response.
+	Count int
+	// Number of requests which received an error response.
+	Errors int
+	// Total time between sending requests and receiving their responses.
+	TotalLatency time.Duration
+	// Latency of the most recent request.
+	LastLatency time.Duration
+}
+
+// Function RequestStats returns the number and latency of the requests
+// sent since the Client was created.
+func (c *Client) RequestStats() RequestStats {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.requestStats
+}
+
 // Function GetHandler returns the handler for the given event type, if
 // it exists.
 func (c *Client) GetHandler(eventType string) func(any) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
 	return c.eventHandlers[eventType]
 }
 
 // Function SetHandler sets the handler for the given event type.
 func (c *Client) SetHandler(eventType string, handler func(any)) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.eventHandlers == nil {
+		c.eventHandlers = make(map[string]func(any))
+	}
 	c.eventHandlers[eventType] = handler
 }
 
+// listener is an internal event handler. Unlike the handlers set with
+// SetHandler, any number of listeners may be registered for an event type.
+type listener struct {
+	fn func(any)
+}
+
+// Function addListener registers fn to be called for every event of the
+// given type. Like handlers, listeners run on the connection's read loop
+// and must not block on requests. The returned function removes the
+// listener.
+func (c *Client) addListener(eventType string, fn func(any)) func() {
+	l := &listener{fn}
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.listeners == nil {
+		c.listeners = make(map[string][]*listener)
+	}
+	// Listener slices are never modified in place, so the read loop can
+	// iterate over them without holding the lock.
+	ls := c.listeners[eventType]
+	c.listeners[eventType] = append(ls[:len(ls):len(ls)], l)
+
+	return func() {
+		c.mx.Lock()
+		defer c.mx.Unlock()
+		ls := c.listeners[eventType]
+		for i, v := range ls {
+			if v == l {
+				n := make([]*listener, 0, len(ls)-1)
+				n = append(n, ls[:i]...)
+				c.listeners[eventType] = append(n, ls[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Function dispatch passes an event to its handler and listeners.
+func (c *Client) dispatch(eventType string, data []byte) {
+	c.mx.Lock()
+	handler, hasHandler := c.eventHandlers[eventType]
+	ls := c.listeners[eventType]
+	c.mx.Unlock()
+
+	conv, ok := eventConverters[eventType]
+	if !ok || (!hasHandler && len(ls) == 0) {
+		return
+	}
+	event := conv(data)
+	if event == nil {
+		return
+	}
+	if hasHandler {
+		handler(event)
+	}
+	for _, l := range ls {
+		l.fn(event)
+	}
+}
+
 func (c *Client) poll() chan error {
 	errch := make(chan error)
 	go func() {
@@ -120,15 +222,33 @@ func (c *Client) poll() chan error {
 			// -  If it has neither, it is an error occurring as a result of
 			//    a previous request.
 			if id, ok := m["message-id"]; ok {
+				c.mx.Lock()
+				if sent, ok := c.sent[id.(string)]; ok {
+					latency := time.Since(sent)
+					c.requestStats.Count++
+					c.requestStats.TotalLatency += latency
+					c.requestStats.LastLatency = latency
+					if m["status"] == "error" {
+						c.requestStats.Errors++
+					}
+					delete(c.sent, id.(string))
+				}
+				c.mx.Unlock()
 				if status, ok := m["status"]; ok {
+					// Requests which are no longer waited for have been
+					// removed from the maps.
 					if status == "error" {
 						errMsg := m["error"]
 						c.mx.Lock()
-						c.errMap[id.(string)] <- errors.New(errMsg.(string))
+						if ch, ok := c.errMap[id.(string)]; ok {
+							ch <- errors.New(errMsg.(string))
+						}
 						c.mx.Unlock()
 					} else {
 						c.mx.Lock()
-						c.recvMap[id.(string)] <- data
+						if ch, ok := c.recvMap[id.(string)]; ok {
+							ch <- data
+						}
 						c.mx.Unlock()
 					}
 				} else {
@@ -143,13 +263,8 @@ func (c *Client) poll() chan error {
 				if err, ok := m["error"]; ok {
 					errch <- errors.New(err.(string))
 				} else {
-					eventType := m["update-type"].(string)
-					if handler, ok := c.eventHandlers[eventType]; ok {
-						event := eventConverters[eventType](data)
-						if event != nil {
-							handler(event)
-						}
-					}
+					eventType, _ := m["update-type"].(string)
+					c.dispatch(eventType, data)
 				}
 			}
 
@@ -165,7 +280,9 @@ func (c *Client) poll() chan error {
 }
 
 func (c *Client) send(data []byte, id string, errch chan error) chan []byte {
-	resch := make(chan []byte)
+	// Buffered, so that the read loop does not block on a response which
+	// is no longer waited for.
+	resch := make(chan []byte, 1)
 	if !c.connected {
 		errch <- errors.New("client not connected")
 		return resch
@@ -175,11 +292,13 @@ func (c *Client) send(data []byte, id string, errch chan error) chan []byte {
 		defer c.mx.Unlock()
 		c.errMap[id] = errch
 		c.recvMap[id] = resch
+		c.sent[id] = time.Now()
 		err := c.conn.WriteMessage(websocket.TextMessage, data)
 		if err != nil {
 			errch <- err
 			delete(c.errMap, id)
 			delete(c.recvMap, id)
+			delete(c.sent, id)
 		}
 	}()
 	return resch
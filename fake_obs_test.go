@@ -0,0 +1,149 @@
+package go_obs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// fakeOBS is a minimal obs-websocket server for tests which need a client
+// but not a running OBS. Requests are answered by handlers registered per
+// request type, each on its own goroutine.
+type fakeOBS struct {
+	t        *testing.T
+	srv      *httptest.Server
+	mx       sync.Mutex
+	wmx      sync.Mutex
+	conn     *websocket.Conn
+	handlers map[string]func(req map[string]any) (map[string]any, error)
+	requests []map[string]any
+}
+
+func newFakeOBS(t *testing.T) *fakeOBS {
+	f := &fakeOBS{
+		t:        t,
+		handlers: make(map[string]func(map[string]any) (map[string]any, error)),
+	}
+	f.handle("GetAuthRequired", func(map[string]any) (map[string]any, error) {
+		return map[string]any{"authRequired": false}, nil
+	})
+	upgrader := websocket.Upgrader{}
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		f.mx.Lock()
+		f.conn = conn
+		f.mx.Unlock()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			req := make(map[string]any)
+			if json.Unmarshal(data, &req) != nil {
+				return
+			}
+			go f.serve(req)
+		}
+	}))
+	t.Cleanup(func() {
+		f.mx.Lock()
+		if f.conn != nil {
+			f.conn.Close()
+		}
+		f.mx.Unlock()
+		f.srv.Close()
+	})
+	return f
+}
+
+// Function handle sets the handler for a request type.
+func (f *fakeOBS) handle(requestType string, fn func(req map[string]any) (map[string]any, error)) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	f.handlers[requestType] = fn
+}
+
+// Function reply sets a handler which always returns the given fields.
+func (f *fakeOBS) reply(requestType string, res map[string]any) {
+	f.handle(requestType, func(map[string]any) (map[string]any, error) {
+		return res, nil
+	})
+}
+
+func (f *fakeOBS) serve(req map[string]any) {
+	requestType, _ := req["request-type"].(string)
+	f.mx.Lock()
+	fn, ok := f.handlers[requestType]
+	f.requests = append(f.requests, req)
+	f.mx.Unlock()
+
+	var res map[string]any
+	var err error
+	if ok {
+		res, err = fn(req)
+	} else {
+		err = errors.New("unknown request: " + requestType)
+	}
+	out := map[string]any{"message-id": req["message-id"], "status": "ok"}
+	for k, v := range res {
+		out[k] = v
+	}
+	if err != nil {
+		out = map[string]any{"message-id": req["message-id"], "status": "error", "error": err.Error()}
+	}
+	f.send(out)
+}
+
+func (f *fakeOBS) send(msg map[string]any) {
+	f.mx.Lock()
+	conn := f.conn
+	f.mx.Unlock()
+	f.wmx.Lock()
+	defer f.wmx.Unlock()
+	conn.WriteJSON(msg)
+}
+
+// Function event sends an event to the client.
+func (f *fakeOBS) event(updateType string, fields map[string]any) {
+	msg := map[string]any{"update-type": updateType}
+	for k, v := range fields {
+		msg[k] = v
+	}
+	f.send(msg)
+}
+
+// Function sent returns the requests of the given type received so far.
+func (f *fakeOBS) sent(requestType string) []map[string]any {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	var out []map[string]any
+	for _, r := range f.requests {
+		if r["request-type"] == requestType {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Function connect returns a client connected to the fake server.
+func (f *fakeOBS) connect() *obs.Client {
+	c := &obs.Client{}
+	_, errch, err := c.Connect(strings.TrimPrefix(f.srv.URL, "http://"))
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	go func() {
+		<-errch
+	}()
+	return c
+}
@@ -0,0 +1,235 @@
+package go_obs
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilenameTemplate is a parsed recording filename formatting string, such as
+// `%CCYY-%MM-%DD %hh-%mm-%ss`. The supported specifiers are those of OBS:
+//
+//	%CCYY  year (4 digits)          %Y  year (4 digits)
+//	%YY    year (2 digits)          %y  year (2 digits)
+//	%MM    month (01-12)            %m  month (01-12)
+//	%DD    day (01-31)              %d  day (01-31)
+//	%hh    hour (00-23)             %H  hour (00-23)
+//	%mm    minute (00-59)           %M  minute (00-59)
+//	%ss    second (00-59)           %S  second (00-59)
+//	%a     abbreviated weekday      %A  weekday
+//	%b     abbreviated month        %B  month
+//	%I     hour (01-12)             %p  AM or PM
+//	%z     time zone offset         %Z  time zone name
+//	%FPS   frame rate               %VF video format
+//	%CRES  canvas resolution        %ORES output resolution
+//	%%     a percent sign
+type FilenameTemplate struct {
+	format string
+	parts  []filenamePart
+}
+
+// filenamePart is either literal text or a specifier (without its `%`).
+type filenamePart struct {
+	text string
+	spec bool
+}
+
+// Specifiers in the order they are matched, so that longer ones come first.
+var filenameSpecifiers = []string{
+	"CCYY", "CRES", "ORES", "FPS",
+	"YY", "MM", "DD", "hh", "mm", "ss", "VF",
+	"a", "A", "b", "B", "d", "H", "I", "m", "M", "p", "S", "y", "Y", "z", "Z",
+}
+
+// Characters which are not allowed in file names on at least one platform.
+// Slashes are allowed, as OBS creates subfolders for them.
+const invalidFilenameChars = `<>:"|?*`
+
+// Function ParseFilenameTemplate parses and validates a filename formatting
+// string. It fails on unknown specifiers and on characters which are not
+// valid in file names.
+func ParseFilenameTemplate(format string) (*FilenameTemplate, error) {
+	if strings.TrimSpace(format) == "" {
+		return nil, errors.New("empty filename format")
+	}
+	t := &FilenameTemplate{format: format}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			t.parts = append(t.parts, filenamePart{text: literal.String()})
+			literal.Reset()
+		}
+	}
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		if ch < 0x20 || strings.IndexByte(invalidFilenameChars, ch) >= 0 {
+			return nil, errors.New("invalid character in filename format at " + strconv.Itoa(i) + ": " + strconv.QuoteRune(rune(ch)))
+		}
+		if ch != '%' {
+			literal.WriteByte(ch)
+			continue
+		}
+		rest := format[i+1:]
+		if strings.HasPrefix(rest, "%") {
+			literal.WriteByte('%')
+			i++
+			continue
+		}
+		spec := ""
+		for _, s := range filenameSpecifiers {
+			if strings.HasPrefix(rest, s) {
+				spec = s
+				break
+			}
+		}
+		if spec == "" {
+			return nil, errors.New("unknown specifier in filename format at " + strconv.Itoa(i) + ": %" + firstRune(rest))
+		}
+		flush()
+		t.parts = append(t.parts, filenamePart{text: spec, spec: true})
+		i += len(spec)
+	}
+	flush()
+	return t, nil
+}
+
+func firstRune(s string) string {
+	for _, r := range s {
+		return string(r)
+	}
+	return ""
+}
+
+// Function String returns the filename formatting string of the template.
+func (t *FilenameTemplate) String() string {
+	return t.format
+}
+
+// Function UsesVideoInfo returns whether the template contains specifiers
+// which depend on the video settings (`%FPS`, `%CRES`, `%ORES` and `%VF`).
+func (t *FilenameTemplate) UsesVideoInfo() bool {
+	for _, p := range t.parts {
+		if p.spec && (p.text == "FPS" || p.text == "CRES" || p.text == "ORES" || p.text == "VF") {
+			return true
+		}
+	}
+	return false
+}
+
+// Function Render returns the file name (without extension) which OBS would
+// produce for a recording started at the given time. The video settings are
+// only needed if UsesVideoInfo returns true, and may be nil otherwise.
+func (t *FilenameTemplate) Render(at time.Time, video *GetVideoInfoResponse) (string, error) {
+	if video == nil && t.UsesVideoInfo() {
+		return "", errors.New("filename format requires video info")
+	}
+	var b strings.Builder
+	for _, p := range t.parts {
+		if !p.spec {
+			b.WriteString(p.text)
+			continue
+		}
+		switch p.text {
+		case "CCYY", "Y":
+			b.WriteString(at.Format("2006"))
+		case "YY", "y":
+			b.WriteString(at.Format("06"))
+		case "MM", "m":
+			b.WriteString(at.Format("01"))
+		case "DD", "d":
+			b.WriteString(at.Format("02"))
+		case "hh", "H":
+			b.WriteString(at.Format("15"))
+		case "mm", "M":
+			b.WriteString(at.Format("04"))
+		case "ss", "S":
+			b.WriteString(at.Format("05"))
+		case "a":
+			b.WriteString(at.Format("Mon"))
+		case "A":
+			b.WriteString(at.Format("Monday"))
+		case "b":
+			b.WriteString(at.Format("Jan"))
+		case "B":
+			b.WriteString(at.Format("January"))
+		case "I":
+			b.WriteString(at.Format("03"))
+		case "p":
+			b.WriteString(at.Format("PM"))
+		case "z":
+			b.WriteString(at.Format("-0700"))
+		case "Z":
+			b.WriteString(at.Format("MST"))
+		case "FPS":
+			if video.Fps == float64(int(video.Fps)) {
+				b.WriteString(strconv.Itoa(int(video.Fps)))
+			} else {
+				b.WriteString(strconv.FormatFloat(video.Fps, 'f', 2, 64))
+			}
+		case "CRES":
+			b.WriteString(strconv.Itoa(video.BaseWidth) + "x" + strconv.Itoa(video.BaseHeight))
+		case "ORES":
+			b.WriteString(strconv.Itoa(video.OutputWidth) + "x" + strconv.Itoa(video.OutputHeight))
+		case "VF":
+			b.WriteString(video.VideoFormat)
+		}
+	}
+	return b.String(), nil
+}
+
+// Function Glob returns a glob pattern, as used by filepath.Match, which
+// matches the file names produced by the template, with any prefix or suffix
+// (such as the "Replay" prefix of replay buffer files).
+func (t *FilenameTemplate) Glob() string {
+	var b strings.Builder
+	b.WriteByte('*')
+	for _, p := range t.parts {
+		if p.spec {
+			b.WriteByte('*')
+			continue
+		}
+		for i := 0; i < len(p.text); i++ {
+			if strings.IndexByte(`*?[]\`, p.text[i]) >= 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(p.text[i])
+		}
+	}
+	b.WriteByte('*')
+	return b.String()
+}
+
+// Function GetFilenameTemplate gets and parses the current filename
+// formatting.
+func (c *Client) GetFilenameTemplate() (*FilenameTemplate, error) {
+	res, err := c.GetFilenameFormatting()
+	if err != nil {
+		return nil, err
+	}
+	return ParseFilenameTemplate(res.FilenameFormatting)
+}
+
+// Function SetFilenameTemplate sets the filename formatting to the given
+// template.
+func (c *Client) SetFilenameTemplate(t *FilenameTemplate) error {
+	_, err := c.SetFilenameFormatting(t.String())
+	return err
+}
+
+// Function PredictFilename returns the file name (without extension) of a
+// recording started now with the current filename formatting.
+func (c *Client) PredictFilename() (string, error) {
+	now := time.Now()
+	t, err := c.GetFilenameTemplate()
+	if err != nil {
+		return "", err
+	}
+	var video *GetVideoInfoResponse
+	if t.UsesVideoInfo() {
+		if video, err = c.GetVideoInfo(); err != nil {
+			return "", err
+		}
+	}
+	return t.Render(now, video)
+}
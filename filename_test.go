@@ -0,0 +1,89 @@
+package go_obs_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestFilenameTemplate(t *testing.T) {
+	at := time.Date(2021, time.March, 7, 14, 5, 9, 0, time.UTC)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%CCYY-%MM-%DD %hh-%mm-%ss", "2021-03-07 14-05-09"},
+		{"%Y%m%d_%H%M%S", "20210307_140509"},
+		{"%YY %a %A %b %B", "21 Sun Sunday Mar March"},
+		{"%I%p %z %Z", "02PM +0000 UTC"},
+		{"100%% %CCYY", "100% 2021"},
+		{"stream/%DD", "stream/07"},
+	}
+	for _, tt := range tests {
+		tmpl, err := obs.ParseFilenameTemplate(tt.format)
+		if err != nil {
+			t.Errorf("%q: %v", tt.format, err)
+			continue
+		}
+		got, err := tmpl.Render(at, nil)
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %q (%v), want %q", tt.format, got, err, tt.want)
+		}
+		if tmpl.String() != tt.format {
+			t.Errorf("%q: string %q", tt.format, tmpl.String())
+		}
+	}
+}
+
+func TestFilenameTemplateVideo(t *testing.T) {
+	tmpl, err := obs.ParseFilenameTemplate("%CRES %ORES %FPS %VF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tmpl.UsesVideoInfo() {
+		t.Error("video specifiers not detected")
+	}
+	if _, err := tmpl.Render(time.Now(), nil); err == nil {
+		t.Error("rendered without video info")
+	}
+	video := &obs.GetVideoInfoResponse{
+		BaseWidth: 1920, BaseHeight: 1080,
+		OutputWidth: 1280, OutputHeight: 720,
+		Fps: 60, VideoFormat: "NV12",
+	}
+	got, err := tmpl.Render(time.Now(), video)
+	if err != nil || got != "1920x1080 1280x720 60 NV12" {
+		t.Errorf("got %q (%v)", got, err)
+	}
+	video.Fps = 29.97
+	if got, _ := tmpl.Render(time.Now(), video); got != "1920x1080 1280x720 29.97 NV12" {
+		t.Errorf("fractional fps: %q", got)
+	}
+}
+
+func TestFilenameTemplateInvalid(t *testing.T) {
+	for _, format := range []string{"", "  ", "%Q", "%", "rec %CCYY%", "a:b", "what?", "x\ty"} {
+		if _, err := obs.ParseFilenameTemplate(format); err == nil {
+			t.Errorf("%q: no error", format)
+		}
+	}
+}
+
+func TestFilenameTemplateGlob(t *testing.T) {
+	tmpl, err := obs.ParseFilenameTemplate("%CCYY-%MM-%DD [%hh]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2021, time.March, 7, 14, 5, 9, 0, time.UTC)
+	name, _ := tmpl.Render(at, nil)
+	for _, s := range []string{name, "Replay " + name, name + " (2)"} {
+		if ok, err := filepath.Match(tmpl.Glob(), s); !ok || err != nil {
+			t.Errorf("%q does not match %q (%v)", s, tmpl.Glob(), err)
+		}
+	}
+	if ok, _ := filepath.Match(tmpl.Glob(), "2021-03-07 14"); ok {
+		t.Error("glob matches without literal brackets")
+	}
+}
@@ -0,0 +1,504 @@
+package go_obs
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// FilterSettings is implemented by the typed settings of each built-in
+// filter kind. Settings which are not described by the typed struct are
+// kept in its Extra map, so that they survive being read and written back.
+type FilterSettings interface {
+	// The filter kind (eg. `gain_filter`) these settings belong to.
+	FilterKind() string
+}
+
+// Function AddTypedFilter adds a new filter of the kind described by the
+// given settings to a source.
+func (c *Client) AddTypedFilter(sourceName string, filterName string, settings FilterSettings) error {
+	if settings == nil {
+		return errors.New("no filter settings")
+	}
+	_, err := c.AddFilterToSource(sourceName, filterName, settings.FilterKind(), settings)
+	return err
+}
+
+// Function GetTypedFilterSettings reads the settings of the given filter
+// into settings, which should be a pointer to one of the typed settings
+// structs.
+func (c *Client) GetTypedFilterSettings(sourceName string, filterName string, settings FilterSettings) error {
+	res, err := c.GetSourceFilterInfo(sourceName, filterName)
+	if err != nil {
+		return err
+	}
+	return remarshal(res.Settings, settings)
+}
+
+// Function SetTypedFilterSettings updates the settings of the given filter.
+// Settings which are left unset are not changed.
+func (c *Client) SetTypedFilterSettings(sourceName string, filterName string, settings FilterSettings) error {
+	_, err := c.SetSourceFilterSettings(sourceName, filterName, settings)
+	return err
+}
+
+// Settings of a color correction filter (`color_filter_v2`).
+type ColorCorrectionFilterSettings struct {
+	// Gamma adjustment (-3 to 3).
+	Gamma *float64 `json:"gamma,omitempty"`
+	// Contrast adjustment (-4 to 4).
+	Contrast *float64 `json:"contrast,omitempty"`
+	// Brightness adjustment (-1 to 1).
+	Brightness *float64 `json:"brightness,omitempty"`
+	// Saturation adjustment (-1 to 5).
+	Saturation *float64 `json:"saturation,omitempty"`
+	// Hue shift (in degrees, -180 to 180).
+	HueShift *float64 `json:"hue_shift,omitempty"`
+	// Opacity (0 to 1).
+	Opacity *float64 `json:"opacity,omitempty"`
+	// Color to multiply with, in ABGR byte order.
+	ColorMultiply *int `json:"color_multiply,omitempty"`
+	// Color to add, in ABGR byte order.
+	ColorAdd *int `json:"color_add,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewColorCorrectionFilter returns color correction settings with
+// the given adjustments.
+func NewColorCorrectionFilter(gamma, contrast, brightness, saturation, hueShift float64) *ColorCorrectionFilterSettings {
+	return &ColorCorrectionFilterSettings{
+		Gamma:      ptr(gamma),
+		Contrast:   ptr(contrast),
+		Brightness: ptr(brightness),
+		Saturation: ptr(saturation),
+		HueShift:   ptr(hueShift),
+	}
+}
+
+func (s ColorCorrectionFilterSettings) FilterKind() string {
+	return "color_filter_v2"
+}
+
+func (s ColorCorrectionFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain ColorCorrectionFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *ColorCorrectionFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain ColorCorrectionFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a chroma key filter (`chroma_key_filter_v2`).
+type ChromaKeyFilterSettings struct {
+	// Key color type. Can be "green", "blue", "magenta" or "custom".
+	KeyColorType *string `json:"key_color_type,omitempty"`
+	// Custom key color, in ABGR byte order.
+	KeyColor *int `json:"key_color,omitempty"`
+	// Similarity (1 to 1000).
+	Similarity *int `json:"similarity,omitempty"`
+	// Smoothness (1 to 1000).
+	Smoothness *int `json:"smoothness,omitempty"`
+	// Key color spill reduction (1 to 1000).
+	Spill *int `json:"spill,omitempty"`
+	// Opacity (0 to 1).
+	Opacity *float64 `json:"opacity,omitempty"`
+	// Contrast adjustment (-4 to 4).
+	Contrast *float64 `json:"contrast,omitempty"`
+	// Brightness adjustment (-1 to 1).
+	Brightness *float64 `json:"brightness,omitempty"`
+	// Gamma adjustment (-1 to 1).
+	Gamma *float64 `json:"gamma,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewChromaKeyFilter returns chroma key settings for the given key
+// color type.
+func NewChromaKeyFilter(keyColorType string, similarity, smoothness int) *ChromaKeyFilterSettings {
+	return &ChromaKeyFilterSettings{
+		KeyColorType: ptr(keyColorType),
+		Similarity:   ptr(similarity),
+		Smoothness:   ptr(smoothness),
+	}
+}
+
+func (s ChromaKeyFilterSettings) FilterKind() string {
+	return "chroma_key_filter_v2"
+}
+
+func (s ChromaKeyFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain ChromaKeyFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *ChromaKeyFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain ChromaKeyFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a crop/pad filter (`crop_filter`).
+type CropPadFilterSettings struct {
+	// Whether the crop is relative to the edges of the source. If false, the
+	// crop is given by Left, Top, Cx and Cy.
+	Relative *bool `json:"relative,omitempty"`
+	// Number of pixels cropped off the left (negative values pad).
+	Left *int `json:"left,omitempty"`
+	// Number of pixels cropped off the top (negative values pad).
+	Top *int `json:"top,omitempty"`
+	// Number of pixels cropped off the right (negative values pad).
+	Right *int `json:"right,omitempty"`
+	// Number of pixels cropped off the bottom (negative values pad).
+	Bottom *int `json:"bottom,omitempty"`
+	// Width of the crop, if not relative.
+	Cx *int `json:"cx,omitempty"`
+	// Height of the crop, if not relative.
+	Cy *int `json:"cy,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewCropPadFilter returns relative crop/pad settings with the
+// given edges.
+func NewCropPadFilter(left, top, right, bottom int) *CropPadFilterSettings {
+	return &CropPadFilterSettings{
+		Relative: ptr(true),
+		Left:     ptr(left),
+		Top:      ptr(top),
+		Right:    ptr(right),
+		Bottom:   ptr(bottom),
+	}
+}
+
+func (s CropPadFilterSettings) FilterKind() string {
+	return "crop_filter"
+}
+
+func (s CropPadFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain CropPadFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *CropPadFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain CropPadFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a noise suppression filter (`noise_suppress_filter_v2`).
+type NoiseSuppressionFilterSettings struct {
+	// Suppression method. Can be "speex", "rnnoise" or "nvafx".
+	Method *string `json:"method,omitempty"`
+	// Suppression level (in dB, -60 to 0). Only used by "speex".
+	SuppressLevel *int `json:"suppress_level,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewNoiseSuppressionFilter returns noise suppression settings
+// using the given method and suppression level.
+func NewNoiseSuppressionFilter(method string, suppressLevel int) *NoiseSuppressionFilterSettings {
+	return &NoiseSuppressionFilterSettings{
+		Method:        ptr(method),
+		SuppressLevel: ptr(suppressLevel),
+	}
+}
+
+func (s NoiseSuppressionFilterSettings) FilterKind() string {
+	return "noise_suppress_filter_v2"
+}
+
+func (s NoiseSuppressionFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain NoiseSuppressionFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *NoiseSuppressionFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain NoiseSuppressionFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a compressor filter (`compressor_filter`).
+type CompressorFilterSettings struct {
+	// Compression ratio (1 to 32).
+	Ratio *float64 `json:"ratio,omitempty"`
+	// Threshold (in dB, -60 to 0).
+	Threshold *float64 `json:"threshold,omitempty"`
+	// Attack time (in milliseconds).
+	AttackTime *int `json:"attack_time,omitempty"`
+	// Release time (in milliseconds).
+	ReleaseTime *int `json:"release_time,omitempty"`
+	// Output gain (in dB, -32 to 32).
+	OutputGain *float64 `json:"output_gain,omitempty"`
+	// Name of the sidechain/ducking source, or "none".
+	SidechainSource *string `json:"sidechain_source,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewCompressorFilter returns compressor settings with the given
+// ratio and threshold.
+func NewCompressorFilter(ratio, threshold float64) *CompressorFilterSettings {
+	return &CompressorFilterSettings{
+		Ratio:     ptr(ratio),
+		Threshold: ptr(threshold),
+	}
+}
+
+func (s CompressorFilterSettings) FilterKind() string {
+	return "compressor_filter"
+}
+
+func (s CompressorFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain CompressorFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *CompressorFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain CompressorFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a gain filter (`gain_filter`).
+type GainFilterSettings struct {
+	// Gain (in dB, -30 to 30).
+	Db *float64 `json:"db,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewGainFilter returns gain settings with the given gain in dB.
+func NewGainFilter(db float64) *GainFilterSettings {
+	return &GainFilterSettings{
+		Db: ptr(db),
+	}
+}
+
+func (s GainFilterSettings) FilterKind() string {
+	return "gain_filter"
+}
+
+func (s GainFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain GainFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *GainFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain GainFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a limiter filter (`limiter_filter`).
+type LimiterFilterSettings struct {
+	// Threshold (in dB, -60 to 0).
+	Threshold *float64 `json:"threshold,omitempty"`
+	// Release time (in milliseconds).
+	ReleaseTime *int `json:"release_time,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewLimiterFilter returns limiter settings with the given
+// threshold.
+func NewLimiterFilter(threshold float64) *LimiterFilterSettings {
+	return &LimiterFilterSettings{
+		Threshold: ptr(threshold),
+	}
+}
+
+func (s LimiterFilterSettings) FilterKind() string {
+	return "limiter_filter"
+}
+
+func (s LimiterFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain LimiterFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *LimiterFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain LimiterFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a noise gate filter (`noise_gate_filter`).
+type NoiseGateFilterSettings struct {
+	// Threshold above which the gate opens (in dB, -96 to 0).
+	OpenThreshold *float64 `json:"open_threshold,omitempty"`
+	// Threshold below which the gate closes (in dB, -96 to 0).
+	CloseThreshold *float64 `json:"close_threshold,omitempty"`
+	// Attack time (in milliseconds).
+	AttackTime *int `json:"attack_time,omitempty"`
+	// Hold time (in milliseconds).
+	HoldTime *int `json:"hold_time,omitempty"`
+	// Release time (in milliseconds).
+	ReleaseTime *int `json:"release_time,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewNoiseGateFilter returns noise gate settings with the given
+// thresholds.
+func NewNoiseGateFilter(openThreshold, closeThreshold float64) *NoiseGateFilterSettings {
+	return &NoiseGateFilterSettings{
+		OpenThreshold:  ptr(openThreshold),
+		CloseThreshold: ptr(closeThreshold),
+	}
+}
+
+func (s NoiseGateFilterSettings) FilterKind() string {
+	return "noise_gate_filter"
+}
+
+func (s NoiseGateFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain NoiseGateFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *NoiseGateFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain NoiseGateFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a scroll filter (`scroll_filter`).
+type ScrollFilterSettings struct {
+	// Horizontal scroll speed (in pixels per second).
+	SpeedX *float64 `json:"speed_x,omitempty"`
+	// Vertical scroll speed (in pixels per second).
+	SpeedY *float64 `json:"speed_y,omitempty"`
+	// Whether the width is limited to Cx.
+	LimitCx *bool `json:"limit_cx,omitempty"`
+	// Limited width.
+	Cx *int `json:"cx,omitempty"`
+	// Whether the height is limited to Cy.
+	LimitCy *bool `json:"limit_cy,omitempty"`
+	// Limited height.
+	Cy *int `json:"cy,omitempty"`
+	// Whether the source loops while scrolling.
+	Loop *bool `json:"loop,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewScrollFilter returns scroll settings with the given speeds.
+func NewScrollFilter(speedX, speedY float64) *ScrollFilterSettings {
+	return &ScrollFilterSettings{
+		SpeedX: ptr(speedX),
+		SpeedY: ptr(speedY),
+	}
+}
+
+func (s ScrollFilterSettings) FilterKind() string {
+	return "scroll_filter"
+}
+
+func (s ScrollFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain ScrollFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *ScrollFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain ScrollFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a sharpen filter (`sharpness_filter_v2`).
+type SharpenFilterSettings struct {
+	// Sharpness (0 to 1).
+	Sharpness *float64 `json:"sharpness,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+// Function NewSharpenFilter returns sharpen settings with the given
+// sharpness.
+func NewSharpenFilter(sharpness float64) *SharpenFilterSettings {
+	return &SharpenFilterSettings{
+		Sharpness: ptr(sharpness),
+	}
+}
+
+func (s SharpenFilterSettings) FilterKind() string {
+	return "sharpness_filter_v2"
+}
+
+func (s SharpenFilterSettings) MarshalJSON() ([]byte, error) {
+	type plain SharpenFilterSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *SharpenFilterSettings) UnmarshalJSON(data []byte) error {
+	type plain SharpenFilterSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a filter kind whose settings are not described by a struct,
+// such as the deprecated first versions of the color correction, chroma
+// key, noise suppression and sharpen filters. Every setting is kept in
+// Extra.
+type UntypedFilterSettings struct {
+	// Filter kind.
+	Kind string
+	// Filter settings.
+	Extra map[string]any
+}
+
+func (s UntypedFilterSettings) FilterKind() string {
+	return s.Kind
+}
+
+func (s UntypedFilterSettings) MarshalJSON() ([]byte, error) {
+	if s.Extra == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(s.Extra)
+}
+
+func (s *UntypedFilterSettings) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.Extra)
+}
+
+// Function filterSettingsFor returns new, empty typed settings for the given
+// filter kind, or nil if the kind has no typed settings.
+func filterSettingsFor(kind string) FilterSettings {
+	switch kind {
+	case "color_filter", "chroma_key_filter", "noise_suppress_filter", "sharpness_filter":
+		// The first versions of these filters use different settings
+		// from the current ones.
+		return &UntypedFilterSettings{Kind: kind}
+	case "color_filter_v2":
+		return &ColorCorrectionFilterSettings{}
+	case "chroma_key_filter_v2":
+		return &ChromaKeyFilterSettings{}
+	case "crop_filter":
+		return &CropPadFilterSettings{}
+	case "noise_suppress_filter_v2":
+		return &NoiseSuppressionFilterSettings{}
+	case "compressor_filter":
+		return &CompressorFilterSettings{}
+	case "gain_filter":
+		return &GainFilterSettings{}
+	case "limiter_filter":
+		return &LimiterFilterSettings{}
+	case "noise_gate_filter":
+		return &NoiseGateFilterSettings{}
+	case "scroll_filter":
+		return &ScrollFilterSettings{}
+	case "sharpness_filter_v2":
+		return &SharpenFilterSettings{}
+	}
+	return nil
+}
+
+// Function DecodeFilterSettings decodes untyped filter settings, such as
+// those of GetSourceFiltersResponse, into the typed settings for the given
+// filter kind. The first versions of filters which have a v2 decode into
+// UntypedFilterSettings, and unknown kinds return an error.
+func DecodeFilterSettings(kind string, settings any) (FilterSettings, error) {
+	s := filterSettingsFor(kind)
+	if s == nil {
+		return nil, errors.New("no typed settings for filter kind: " + kind)
+	}
+	if err := remarshal(settings, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
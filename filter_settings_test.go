@@ -0,0 +1,87 @@
+package go_obs_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestFilterSettingsRoundTrip(t *testing.T) {
+	tests := []struct {
+		kind     string
+		wantKind string
+		settings string
+	}{
+		{"color_filter_v2", "color_filter_v2", `{"gamma":0.5,"opacity":1,"color_add":255}`},
+		{"chroma_key_filter_v2", "chroma_key_filter_v2", `{"key_color_type":"green","similarity":400,"smoothness":80}`},
+		{"crop_filter", "crop_filter", `{"relative":true,"left":10,"top":0}`},
+		{"noise_suppress_filter_v2", "noise_suppress_filter_v2", `{"method":"rnnoise","suppress_level":-30}`},
+		{"compressor_filter", "compressor_filter", `{"ratio":10,"threshold":-18,"sidechain_source":""}`},
+		{"gain_filter", "gain_filter", `{"db":-3.5}`},
+		{"limiter_filter", "limiter_filter", `{"threshold":-6,"release_time":60}`},
+		{"noise_gate_filter", "noise_gate_filter", `{"open_threshold":-26,"close_threshold":-32}`},
+		{"scroll_filter", "scroll_filter", `{"speed_x":100,"speed_y":0,"loop":true}`},
+		{"sharpness_filter_v2", "sharpness_filter_v2", `{"sharpness":0.08}`},
+		{"color_filter", "color_filter", `{"gamma":0.5,"color":4294967295}`},
+		{"sharpness_filter", "sharpness_filter", `{"sharpness":0.08}`},
+	}
+	for _, tt := range tests {
+		// Unknown settings must survive decoding and encoding.
+		in := map[string]any{}
+		if err := json.Unmarshal([]byte(tt.settings), &in); err != nil {
+			t.Fatal(err)
+		}
+		in["future_setting"] = []any{"a", 1.0}
+
+		s, err := obs.DecodeFilterSettings(tt.kind, in)
+		if err != nil {
+			t.Errorf("%s: %v", tt.kind, err)
+			continue
+		}
+		if s.FilterKind() != tt.wantKind {
+			t.Errorf("%s: kind %s", tt.kind, s.FilterKind())
+		}
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := map[string]any{}
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("%s: round trip\n got %s\nwant %v", tt.kind, data, in)
+		}
+	}
+
+	// The first versions of filters with a v2 are not decoded into the
+	// v2 settings.
+	for _, kind := range []string{"color_filter", "chroma_key_filter", "noise_suppress_filter", "sharpness_filter"} {
+		s, err := obs.DecodeFilterSettings(kind, map[string]any{"opacity": 50.0})
+		if err != nil {
+			t.Errorf("%s: %v", kind, err)
+			continue
+		}
+		untyped, ok := s.(*obs.UntypedFilterSettings)
+		if !ok || untyped.Extra["opacity"] != 50.0 {
+			t.Errorf("%s: decoded as %#v", kind, s)
+		}
+	}
+
+	if _, err := obs.DecodeFilterSettings("not_a_filter", map[string]any{}); err == nil {
+		t.Error("decoded unknown filter kind")
+	}
+}
+
+func TestFilterSettingsConstructors(t *testing.T) {
+	data, err := json.Marshal(obs.NewGainFilter(-6))
+	if err != nil || string(data) != `{"db":-6}` {
+		t.Errorf("gain: %s (%v)", data, err)
+	}
+	data, err = json.Marshal(obs.NewChromaKeyFilter("magenta", 300, 60))
+	if err != nil || string(data) != `{"key_color_type":"magenta","similarity":300,"smoothness":60}` {
+		t.Errorf("chroma key: %s (%v)", data, err)
+	}
+}
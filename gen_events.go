@@ -1,6 +1,9 @@
 package go_obs
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 // A custom broadcast message, sent by the server, requested by one of the
 // websocket clients.
@@ -1157,3 +1160,69 @@ var eventConverters = map[string]func([]byte) any{
 		return evt
 	},
 }
+
+var eventTypes = map[string]reflect.Type{
+	"BroadcastCustomMessage":        reflect.TypeOf(BroadcastCustomMessageEvent{}),
+	"Exiting":                       reflect.TypeOf(ExitingEvent{}),
+	"Heartbeat":                     reflect.TypeOf(HeartbeatEvent{}),
+	"MediaEnded":                    reflect.TypeOf(MediaEndedEvent{}),
+	"MediaNext":                     reflect.TypeOf(MediaNextEvent{}),
+	"MediaPaused":                   reflect.TypeOf(MediaPausedEvent{}),
+	"MediaPlaying":                  reflect.TypeOf(MediaPlayingEvent{}),
+	"MediaPrevious":                 reflect.TypeOf(MediaPreviousEvent{}),
+	"MediaRestarted":                reflect.TypeOf(MediaRestartedEvent{}),
+	"MediaStarted":                  reflect.TypeOf(MediaStartedEvent{}),
+	"MediaStopped":                  reflect.TypeOf(MediaStoppedEvent{}),
+	"PreviewSceneChanged":           reflect.TypeOf(PreviewSceneChangedEvent{}),
+	"ProfileChanged":                reflect.TypeOf(ProfileChangedEvent{}),
+	"ProfileListChanged":            reflect.TypeOf(ProfileListChangedEvent{}),
+	"RecordingPaused":               reflect.TypeOf(RecordingPausedEvent{}),
+	"RecordingResumed":              reflect.TypeOf(RecordingResumedEvent{}),
+	"RecordingStarted":              reflect.TypeOf(RecordingStartedEvent{}),
+	"RecordingStarting":             reflect.TypeOf(RecordingStartingEvent{}),
+	"RecordingStopped":              reflect.TypeOf(RecordingStoppedEvent{}),
+	"RecordingStopping":             reflect.TypeOf(RecordingStoppingEvent{}),
+	"ReplayStarted":                 reflect.TypeOf(ReplayStartedEvent{}),
+	"ReplayStarting":                reflect.TypeOf(ReplayStartingEvent{}),
+	"ReplayStopped":                 reflect.TypeOf(ReplayStoppedEvent{}),
+	"ReplayStopping":                reflect.TypeOf(ReplayStoppingEvent{}),
+	"SceneCollectionChanged":        reflect.TypeOf(SceneCollectionChangedEvent{}),
+	"SceneCollectionListChanged":    reflect.TypeOf(SceneCollectionListChangedEvent{}),
+	"SceneItemAdded":                reflect.TypeOf(SceneItemAddedEvent{}),
+	"SceneItemDeselected":           reflect.TypeOf(SceneItemDeselectedEvent{}),
+	"SceneItemLockChanged":          reflect.TypeOf(SceneItemLockChangedEvent{}),
+	"SceneItemRemoved":              reflect.TypeOf(SceneItemRemovedEvent{}),
+	"SceneItemSelected":             reflect.TypeOf(SceneItemSelectedEvent{}),
+	"SceneItemTransformChanged":     reflect.TypeOf(SceneItemTransformChangedEvent{}),
+	"SceneItemVisibilityChanged":    reflect.TypeOf(SceneItemVisibilityChangedEvent{}),
+	"ScenesChanged":                 reflect.TypeOf(ScenesChangedEvent{}),
+	"SourceAudioActivated":          reflect.TypeOf(SourceAudioActivatedEvent{}),
+	"SourceAudioDeactivated":        reflect.TypeOf(SourceAudioDeactivatedEvent{}),
+	"SourceAudioMixersChanged":      reflect.TypeOf(SourceAudioMixersChangedEvent{}),
+	"SourceAudioSyncOffsetChanged":  reflect.TypeOf(SourceAudioSyncOffsetChangedEvent{}),
+	"SourceCreated":                 reflect.TypeOf(SourceCreatedEvent{}),
+	"SourceDestroyed":               reflect.TypeOf(SourceDestroyedEvent{}),
+	"SourceFilterAdded":             reflect.TypeOf(SourceFilterAddedEvent{}),
+	"SourceFilterRemoved":           reflect.TypeOf(SourceFilterRemovedEvent{}),
+	"SourceFilterVisibilityChanged": reflect.TypeOf(SourceFilterVisibilityChangedEvent{}),
+	"SourceFiltersReordered":        reflect.TypeOf(SourceFiltersReorderedEvent{}),
+	"SourceMuteStateChanged":        reflect.TypeOf(SourceMuteStateChangedEvent{}),
+	"SourceOrderChanged":            reflect.TypeOf(SourceOrderChangedEvent{}),
+	"SourceRenamed":                 reflect.TypeOf(SourceRenamedEvent{}),
+	"SourceVolumeChanged":           reflect.TypeOf(SourceVolumeChangedEvent{}),
+	"StreamStarted":                 reflect.TypeOf(StreamStartedEvent{}),
+	"StreamStarting":                reflect.TypeOf(StreamStartingEvent{}),
+	"StreamStatus":                  reflect.TypeOf(StreamStatusEvent{}),
+	"StreamStopped":                 reflect.TypeOf(StreamStoppedEvent{}),
+	"StreamStopping":                reflect.TypeOf(StreamStoppingEvent{}),
+	"StudioModeSwitched":            reflect.TypeOf(StudioModeSwitchedEvent{}),
+	"SwitchScenes":                  reflect.TypeOf(SwitchScenesEvent{}),
+	"SwitchTransition":              reflect.TypeOf(SwitchTransitionEvent{}),
+	"TransitionBegin":               reflect.TypeOf(TransitionBeginEvent{}),
+	"TransitionDurationChanged":     reflect.TypeOf(TransitionDurationChangedEvent{}),
+	"TransitionEnd":                 reflect.TypeOf(TransitionEndEvent{}),
+	"TransitionListChanged":         reflect.TypeOf(TransitionListChangedEvent{}),
+	"TransitionVideoEnd":            reflect.TypeOf(TransitionVideoEndEvent{}),
+	"VirtualCamStarted":             reflect.TypeOf(VirtualCamStartedEvent{}),
+	"VirtualCamStopped":             reflect.TypeOf(VirtualCamStoppedEvent{}),
+}
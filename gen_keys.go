@@ -0,0 +1,949 @@
+package go_obs
+
+// Keys, named after their OBS key IDs without the `OBS_KEY_` prefix.
+const (
+	KeyNone Key = iota
+	KeyReturn
+	KeyEnter
+	KeyEscape
+	KeyTab
+	KeyBacktab
+	KeyBackspace
+	KeyInsert
+	KeyDelete
+	KeyPause
+	KeyPrint
+	KeySysReq
+	KeyClear
+	KeyHome
+	KeyEnd
+	KeyLeft
+	KeyUp
+	KeyRight
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyShift
+	KeyControl
+	KeyMeta
+	KeyAlt
+	KeyAltGr
+	KeyCapsLock
+	KeyNumLock
+	KeyScrollLock
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	KeyF13
+	KeyF14
+	KeyF15
+	KeyF16
+	KeyF17
+	KeyF18
+	KeyF19
+	KeyF20
+	KeyF21
+	KeyF22
+	KeyF23
+	KeyF24
+	KeyF25
+	KeyF26
+	KeyF27
+	KeyF28
+	KeyF29
+	KeyF30
+	KeyF31
+	KeyF32
+	KeyF33
+	KeyF34
+	KeyF35
+	KeyMenu
+	KeyHyperL
+	KeyHyperR
+	KeyHelp
+	KeyDirectionL
+	KeyDirectionR
+	KeySpace
+	KeyExclam
+	KeyQuoteDbl
+	KeyNumberSign
+	KeyDollar
+	KeyPercent
+	KeyAmpersand
+	KeyApostrophe
+	KeyParenLeft
+	KeyParenRight
+	KeyAsterisk
+	KeyPlus
+	KeyComma
+	KeyMinus
+	KeyPeriod
+	KeySlash
+	Key0
+	Key1
+	Key2
+	Key3
+	Key4
+	Key5
+	Key6
+	Key7
+	Key8
+	Key9
+	KeyNumEqual
+	KeyNumAsterisk
+	KeyNumPlus
+	KeyNumComma
+	KeyNumMinus
+	KeyNumPeriod
+	KeyNumSlash
+	KeyNum0
+	KeyNum1
+	KeyNum2
+	KeyNum3
+	KeyNum4
+	KeyNum5
+	KeyNum6
+	KeyNum7
+	KeyNum8
+	KeyNum9
+	KeyColon
+	KeySemicolon
+	KeyQuote
+	KeyLess
+	KeyEqual
+	KeyGreater
+	KeyQuestion
+	KeyAt
+	KeyA
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+	KeyBracketLeft
+	KeyBackslash
+	KeyBracketRight
+	KeyAsciiCircum
+	KeyUnderscore
+	KeyQuoteLeft
+	KeyBraceLeft
+	KeyBar
+	KeyBraceRight
+	KeyAsciiTilde
+	KeyNobreakspace
+	KeyExclamdown
+	KeyCent
+	KeySterling
+	KeyCurrency
+	KeyYen
+	KeyBrokenbar
+	KeySection
+	KeyDiaeresis
+	KeyCopyright
+	KeyOrdfeminine
+	KeyGuillemotleft
+	KeyNotsign
+	KeyHyphen
+	KeyRegistered
+	KeyMacron
+	KeyDegree
+	KeyPlusminus
+	KeyTwosuperior
+	KeyThreesuperior
+	KeyAcute
+	KeyMu
+	KeyParagraph
+	KeyPeriodcentered
+	KeyCedilla
+	KeyOnesuperior
+	KeyMasculine
+	KeyGuillemotright
+	KeyOnequarter
+	KeyOnehalf
+	KeyThreequarters
+	KeyQuestiondown
+	KeyAgrave
+	KeyAacute
+	KeyAcircumflex
+	KeyAtilde
+	KeyAdiaeresis
+	KeyAring
+	KeyAe
+	KeyCcedilla
+	KeyEgrave
+	KeyEacute
+	KeyEcircumflex
+	KeyEdiaeresis
+	KeyIgrave
+	KeyIacute
+	KeyIcircumflex
+	KeyIdiaeresis
+	KeyEth
+	KeyNtilde
+	KeyOgrave
+	KeyOacute
+	KeyOcircumflex
+	KeyOtilde
+	KeyOdiaeresis
+	KeyMultiply
+	KeyOoblique
+	KeyUgrave
+	KeyUacute
+	KeyUcircumflex
+	KeyUdiaeresis
+	KeyYacute
+	KeyThorn
+	KeySsharp
+	KeyDivision
+	KeyYdiaeresis
+	KeyMultiKey
+	KeyCodeinput
+	KeySinglecandidate
+	KeyMultiplecandidate
+	KeyPreviouscandidate
+	KeyModeSwitch
+	KeyKanji
+	KeyMuhenkan
+	KeyHenkan
+	KeyRomaji
+	KeyHiragana
+	KeyKatakana
+	KeyHiraganaKatakana
+	KeyZenkaku
+	KeyHankaku
+	KeyZenkakuHankaku
+	KeyTouroku
+	KeyMassyo
+	KeyKanaLock
+	KeyKanaShift
+	KeyEisuShift
+	KeyEisuToggle
+	KeyHangul
+	KeyHangulStart
+	KeyHangulEnd
+	KeyHangulHanja
+	KeyHangulJamo
+	KeyHangulRomaja
+	KeyHangulJeonja
+	KeyHangulBanja
+	KeyHangulPrehanja
+	KeyHangulPosthanja
+	KeyHangulSpecial
+	KeyDeadGrave
+	KeyDeadAcute
+	KeyDeadCircumflex
+	KeyDeadTilde
+	KeyDeadMacron
+	KeyDeadBreve
+	KeyDeadAbovedot
+	KeyDeadDiaeresis
+	KeyDeadAbovering
+	KeyDeadDoubleacute
+	KeyDeadCaron
+	KeyDeadCedilla
+	KeyDeadOgonek
+	KeyDeadIota
+	KeyDeadVoicedSound
+	KeyDeadSemivoicedSound
+	KeyDeadBelowdot
+	KeyDeadHook
+	KeyDeadHorn
+	KeyBack
+	KeyForward
+	KeyStop
+	KeyRefresh
+	KeyVolumeDown
+	KeyVolumeMute
+	KeyVolumeUp
+	KeyBassboost
+	KeyBassup
+	KeyBassdown
+	KeyTrebleup
+	KeyTrebledown
+	KeyMediaPlay
+	KeyMediaStop
+	KeyMediaPrevious
+	KeyMediaNext
+	KeyMediaRecord
+	KeyMediaPause
+	KeyMediaTogglePlayPause
+	KeyHomepage
+	KeyFavorites
+	KeySearch
+	KeyStandby
+	KeyOpenurl
+	KeyLaunchmail
+	KeyLaunchmedia
+	KeyLaunch0
+	KeyLaunch1
+	KeyLaunch2
+	KeyLaunch3
+	KeyLaunch4
+	KeyLaunch5
+	KeyLaunch6
+	KeyLaunch7
+	KeyLaunch8
+	KeyLaunch9
+	KeyLauncha
+	KeyLaunchb
+	KeyLaunchc
+	KeyLaunchd
+	KeyLaunche
+	KeyLaunchf
+	KeyLaunchg
+	KeyLaunchh
+	KeyMonbrightnessup
+	KeyMonbrightnessdown
+	KeyKeyboardlightonoff
+	KeyKeyboardbrightnessup
+	KeyKeyboardbrightnessdown
+	KeyPoweroff
+	KeyWakeup
+	KeyEject
+	KeyScreensaver
+	KeyWww
+	KeyMemo
+	KeyLightbulb
+	KeyShop
+	KeyHistory
+	KeyAddfavorite
+	KeyHotlinks
+	KeyBrightnessadjust
+	KeyFinance
+	KeyCommunity
+	KeyAudiorewind
+	KeyBackforward
+	KeyApplicationleft
+	KeyApplicationright
+	KeyBook
+	KeyCd
+	KeyCalculator
+	KeyTodolist
+	KeyCleargrab
+	KeyClose
+	KeyCopy
+	KeyCut
+	KeyDisplay
+	KeyDos
+	KeyDocuments
+	KeyExcel
+	KeyExplorer
+	KeyGame
+	KeyGo
+	KeyItouch
+	KeyLogoff
+	KeyMarket
+	KeyMeeting
+	KeyMenukb
+	KeyMenupb
+	KeyMysites
+	KeyNews
+	KeyOfficehome
+	KeyOption
+	KeyPaste
+	KeyPhone
+	KeyCalendar
+	KeyReply
+	KeyReload
+	KeyRotatewindows
+	KeyRotationpb
+	KeyRotationkb
+	KeySave
+	KeySend
+	KeySpell
+	KeySplitscreen
+	KeySupport
+	KeyTaskpane
+	KeyTerminal
+	KeyTools
+	KeyTravel
+	KeyVideo
+	KeyWord
+	KeyXfer
+	KeyZoomin
+	KeyZoomout
+	KeyAway
+	KeyMessenger
+	KeyWebcam
+	KeyMailforward
+	KeyPictures
+	KeyMusic
+	KeyBattery
+	KeyBluetooth
+	KeyWlan
+	KeyUwb
+	KeyAudioforward
+	KeyAudiorepeat
+	KeyAudiorandomplay
+	KeySubtitle
+	KeyAudiocycletrack
+	KeyTime
+	KeyHibernate
+	KeyView
+	KeyTopmenu
+	KeyPowerdown
+	KeySuspend
+	KeyContrastadjust
+	KeyMedialast
+	KeyCall
+	KeyCamera
+	KeyCamerafocus
+	KeyContext1
+	KeyContext2
+	KeyContext3
+	KeyContext4
+	KeyFlip
+	KeyHangup
+	KeyNo
+	KeySelect
+	KeyYes
+	KeyTogglecallhangup
+	KeyVoicedial
+	KeyLastnumberredial
+	KeyExecute
+	KeyPrinter
+	KeyPlay
+	KeySleep
+	KeyZoom
+	KeyCancel
+	KeyMouse1
+	KeyMouse2
+	KeyMouse3
+	KeyMouse4
+	KeyMouse5
+	KeyMouse6
+	KeyMouse7
+	KeyMouse8
+	KeyMouse9
+	KeyMouse10
+	KeyMouse11
+	KeyMouse12
+	KeyMouse13
+	KeyMouse14
+	KeyMouse15
+	KeyMouse16
+	KeyMouse17
+	KeyMouse18
+	KeyMouse19
+	KeyMouse20
+	KeyMouse21
+	KeyMouse22
+	KeyMouse23
+	KeyMouse24
+	KeyMouse25
+	KeyMouse26
+	KeyMouse27
+	KeyMouse28
+	KeyMouse29
+	KeyBackslashRt102
+	KeyOpen
+	KeyFind
+	KeyRedo
+	KeyUndo
+	KeyFront
+	KeyProps
+)
+
+var keyNames = [...]struct {
+	id   string
+	name string
+}{
+	KeyNone:                   {"OBS_KEY_NONE", "None"},
+	KeyReturn:                 {"OBS_KEY_RETURN", "Return"},
+	KeyEnter:                  {"OBS_KEY_ENTER", "Enter"},
+	KeyEscape:                 {"OBS_KEY_ESCAPE", "Escape"},
+	KeyTab:                    {"OBS_KEY_TAB", "Tab"},
+	KeyBacktab:                {"OBS_KEY_BACKTAB", "Backtab"},
+	KeyBackspace:              {"OBS_KEY_BACKSPACE", "Backspace"},
+	KeyInsert:                 {"OBS_KEY_INSERT", "Insert"},
+	KeyDelete:                 {"OBS_KEY_DELETE", "Delete"},
+	KeyPause:                  {"OBS_KEY_PAUSE", "Pause"},
+	KeyPrint:                  {"OBS_KEY_PRINT", "Print"},
+	KeySysReq:                 {"OBS_KEY_SYSREQ", "SysReq"},
+	KeyClear:                  {"OBS_KEY_CLEAR", "Clear"},
+	KeyHome:                   {"OBS_KEY_HOME", "Home"},
+	KeyEnd:                    {"OBS_KEY_END", "End"},
+	KeyLeft:                   {"OBS_KEY_LEFT", "Left"},
+	KeyUp:                     {"OBS_KEY_UP", "Up"},
+	KeyRight:                  {"OBS_KEY_RIGHT", "Right"},
+	KeyDown:                   {"OBS_KEY_DOWN", "Down"},
+	KeyPageUp:                 {"OBS_KEY_PAGEUP", "PageUp"},
+	KeyPageDown:               {"OBS_KEY_PAGEDOWN", "PageDown"},
+	KeyShift:                  {"OBS_KEY_SHIFT", "Shift"},
+	KeyControl:                {"OBS_KEY_CONTROL", "Control"},
+	KeyMeta:                   {"OBS_KEY_META", "Meta"},
+	KeyAlt:                    {"OBS_KEY_ALT", "Alt"},
+	KeyAltGr:                  {"OBS_KEY_ALTGR", "AltGr"},
+	KeyCapsLock:               {"OBS_KEY_CAPSLOCK", "CapsLock"},
+	KeyNumLock:                {"OBS_KEY_NUMLOCK", "NumLock"},
+	KeyScrollLock:             {"OBS_KEY_SCROLLLOCK", "ScrollLock"},
+	KeyF1:                     {"OBS_KEY_F1", "F1"},
+	KeyF2:                     {"OBS_KEY_F2", "F2"},
+	KeyF3:                     {"OBS_KEY_F3", "F3"},
+	KeyF4:                     {"OBS_KEY_F4", "F4"},
+	KeyF5:                     {"OBS_KEY_F5", "F5"},
+	KeyF6:                     {"OBS_KEY_F6", "F6"},
+	KeyF7:                     {"OBS_KEY_F7", "F7"},
+	KeyF8:                     {"OBS_KEY_F8", "F8"},
+	KeyF9:                     {"OBS_KEY_F9", "F9"},
+	KeyF10:                    {"OBS_KEY_F10", "F10"},
+	KeyF11:                    {"OBS_KEY_F11", "F11"},
+	KeyF12:                    {"OBS_KEY_F12", "F12"},
+	KeyF13:                    {"OBS_KEY_F13", "F13"},
+	KeyF14:                    {"OBS_KEY_F14", "F14"},
+	KeyF15:                    {"OBS_KEY_F15", "F15"},
+	KeyF16:                    {"OBS_KEY_F16", "F16"},
+	KeyF17:                    {"OBS_KEY_F17", "F17"},
+	KeyF18:                    {"OBS_KEY_F18", "F18"},
+	KeyF19:                    {"OBS_KEY_F19", "F19"},
+	KeyF20:                    {"OBS_KEY_F20", "F20"},
+	KeyF21:                    {"OBS_KEY_F21", "F21"},
+	KeyF22:                    {"OBS_KEY_F22", "F22"},
+	KeyF23:                    {"OBS_KEY_F23", "F23"},
+	KeyF24:                    {"OBS_KEY_F24", "F24"},
+	KeyF25:                    {"OBS_KEY_F25", "F25"},
+	KeyF26:                    {"OBS_KEY_F26", "F26"},
+	KeyF27:                    {"OBS_KEY_F27", "F27"},
+	KeyF28:                    {"OBS_KEY_F28", "F28"},
+	KeyF29:                    {"OBS_KEY_F29", "F29"},
+	KeyF30:                    {"OBS_KEY_F30", "F30"},
+	KeyF31:                    {"OBS_KEY_F31", "F31"},
+	KeyF32:                    {"OBS_KEY_F32", "F32"},
+	KeyF33:                    {"OBS_KEY_F33", "F33"},
+	KeyF34:                    {"OBS_KEY_F34", "F34"},
+	KeyF35:                    {"OBS_KEY_F35", "F35"},
+	KeyMenu:                   {"OBS_KEY_MENU", "Menu"},
+	KeyHyperL:                 {"OBS_KEY_HYPER_L", "HyperL"},
+	KeyHyperR:                 {"OBS_KEY_HYPER_R", "HyperR"},
+	KeyHelp:                   {"OBS_KEY_HELP", "Help"},
+	KeyDirectionL:             {"OBS_KEY_DIRECTION_L", "DirectionL"},
+	KeyDirectionR:             {"OBS_KEY_DIRECTION_R", "DirectionR"},
+	KeySpace:                  {"OBS_KEY_SPACE", "Space"},
+	KeyExclam:                 {"OBS_KEY_EXCLAM", "!"},
+	KeyQuoteDbl:               {"OBS_KEY_QUOTEDBL", "\""},
+	KeyNumberSign:             {"OBS_KEY_NUMBERSIGN", "#"},
+	KeyDollar:                 {"OBS_KEY_DOLLAR", "$"},
+	KeyPercent:                {"OBS_KEY_PERCENT", "%"},
+	KeyAmpersand:              {"OBS_KEY_AMPERSAND", "&"},
+	KeyApostrophe:             {"OBS_KEY_APOSTROPHE", "'"},
+	KeyParenLeft:              {"OBS_KEY_PARENLEFT", "("},
+	KeyParenRight:             {"OBS_KEY_PARENRIGHT", ")"},
+	KeyAsterisk:               {"OBS_KEY_ASTERISK", "*"},
+	KeyPlus:                   {"OBS_KEY_PLUS", "+"},
+	KeyComma:                  {"OBS_KEY_COMMA", ","},
+	KeyMinus:                  {"OBS_KEY_MINUS", "-"},
+	KeyPeriod:                 {"OBS_KEY_PERIOD", "."},
+	KeySlash:                  {"OBS_KEY_SLASH", "/"},
+	Key0:                      {"OBS_KEY_0", "0"},
+	Key1:                      {"OBS_KEY_1", "1"},
+	Key2:                      {"OBS_KEY_2", "2"},
+	Key3:                      {"OBS_KEY_3", "3"},
+	Key4:                      {"OBS_KEY_4", "4"},
+	Key5:                      {"OBS_KEY_5", "5"},
+	Key6:                      {"OBS_KEY_6", "6"},
+	Key7:                      {"OBS_KEY_7", "7"},
+	Key8:                      {"OBS_KEY_8", "8"},
+	Key9:                      {"OBS_KEY_9", "9"},
+	KeyNumEqual:               {"OBS_KEY_NUMEQUAL", "NumEqual"},
+	KeyNumAsterisk:            {"OBS_KEY_NUMASTERISK", "NumAsterisk"},
+	KeyNumPlus:                {"OBS_KEY_NUMPLUS", "NumPlus"},
+	KeyNumComma:               {"OBS_KEY_NUMCOMMA", "NumComma"},
+	KeyNumMinus:               {"OBS_KEY_NUMMINUS", "NumMinus"},
+	KeyNumPeriod:              {"OBS_KEY_NUMPERIOD", "NumPeriod"},
+	KeyNumSlash:               {"OBS_KEY_NUMSLASH", "NumSlash"},
+	KeyNum0:                   {"OBS_KEY_NUM0", "Num0"},
+	KeyNum1:                   {"OBS_KEY_NUM1", "Num1"},
+	KeyNum2:                   {"OBS_KEY_NUM2", "Num2"},
+	KeyNum3:                   {"OBS_KEY_NUM3", "Num3"},
+	KeyNum4:                   {"OBS_KEY_NUM4", "Num4"},
+	KeyNum5:                   {"OBS_KEY_NUM5", "Num5"},
+	KeyNum6:                   {"OBS_KEY_NUM6", "Num6"},
+	KeyNum7:                   {"OBS_KEY_NUM7", "Num7"},
+	KeyNum8:                   {"OBS_KEY_NUM8", "Num8"},
+	KeyNum9:                   {"OBS_KEY_NUM9", "Num9"},
+	KeyColon:                  {"OBS_KEY_COLON", ":"},
+	KeySemicolon:              {"OBS_KEY_SEMICOLON", ";"},
+	KeyQuote:                  {"OBS_KEY_QUOTE", "Quote"},
+	KeyLess:                   {"OBS_KEY_LESS", "<"},
+	KeyEqual:                  {"OBS_KEY_EQUAL", "="},
+	KeyGreater:                {"OBS_KEY_GREATER", ">"},
+	KeyQuestion:               {"OBS_KEY_QUESTION", "?"},
+	KeyAt:                     {"OBS_KEY_AT", "@"},
+	KeyA:                      {"OBS_KEY_A", "A"},
+	KeyB:                      {"OBS_KEY_B", "B"},
+	KeyC:                      {"OBS_KEY_C", "C"},
+	KeyD:                      {"OBS_KEY_D", "D"},
+	KeyE:                      {"OBS_KEY_E", "E"},
+	KeyF:                      {"OBS_KEY_F", "F"},
+	KeyG:                      {"OBS_KEY_G", "G"},
+	KeyH:                      {"OBS_KEY_H", "H"},
+	KeyI:                      {"OBS_KEY_I", "I"},
+	KeyJ:                      {"OBS_KEY_J", "J"},
+	KeyK:                      {"OBS_KEY_K", "K"},
+	KeyL:                      {"OBS_KEY_L", "L"},
+	KeyM:                      {"OBS_KEY_M", "M"},
+	KeyN:                      {"OBS_KEY_N", "N"},
+	KeyO:                      {"OBS_KEY_O", "O"},
+	KeyP:                      {"OBS_KEY_P", "P"},
+	KeyQ:                      {"OBS_KEY_Q", "Q"},
+	KeyR:                      {"OBS_KEY_R", "R"},
+	KeyS:                      {"OBS_KEY_S", "S"},
+	KeyT:                      {"OBS_KEY_T", "T"},
+	KeyU:                      {"OBS_KEY_U", "U"},
+	KeyV:                      {"OBS_KEY_V", "V"},
+	KeyW:                      {"OBS_KEY_W", "W"},
+	KeyX:                      {"OBS_KEY_X", "X"},
+	KeyY:                      {"OBS_KEY_Y", "Y"},
+	KeyZ:                      {"OBS_KEY_Z", "Z"},
+	KeyBracketLeft:            {"OBS_KEY_BRACKETLEFT", "["},
+	KeyBackslash:              {"OBS_KEY_BACKSLASH", "\\"},
+	KeyBracketRight:           {"OBS_KEY_BRACKETRIGHT", "]"},
+	KeyAsciiCircum:            {"OBS_KEY_ASCIICIRCUM", "^"},
+	KeyUnderscore:             {"OBS_KEY_UNDERSCORE", "_"},
+	KeyQuoteLeft:              {"OBS_KEY_QUOTELEFT", "`"},
+	KeyBraceLeft:              {"OBS_KEY_BRACELEFT", "{"},
+	KeyBar:                    {"OBS_KEY_BAR", "|"},
+	KeyBraceRight:             {"OBS_KEY_BRACERIGHT", "}"},
+	KeyAsciiTilde:             {"OBS_KEY_ASCIITILDE", "~"},
+	KeyNobreakspace:           {"OBS_KEY_NOBREAKSPACE", "Nobreakspace"},
+	KeyExclamdown:             {"OBS_KEY_EXCLAMDOWN", "Exclamdown"},
+	KeyCent:                   {"OBS_KEY_CENT", "Cent"},
+	KeySterling:               {"OBS_KEY_STERLING", "Sterling"},
+	KeyCurrency:               {"OBS_KEY_CURRENCY", "Currency"},
+	KeyYen:                    {"OBS_KEY_YEN", "Yen"},
+	KeyBrokenbar:              {"OBS_KEY_BROKENBAR", "Brokenbar"},
+	KeySection:                {"OBS_KEY_SECTION", "Section"},
+	KeyDiaeresis:              {"OBS_KEY_DIAERESIS", "Diaeresis"},
+	KeyCopyright:              {"OBS_KEY_COPYRIGHT", "Copyright"},
+	KeyOrdfeminine:            {"OBS_KEY_ORDFEMININE", "Ordfeminine"},
+	KeyGuillemotleft:          {"OBS_KEY_GUILLEMOTLEFT", "Guillemotleft"},
+	KeyNotsign:                {"OBS_KEY_NOTSIGN", "Notsign"},
+	KeyHyphen:                 {"OBS_KEY_HYPHEN", "Hyphen"},
+	KeyRegistered:             {"OBS_KEY_REGISTERED", "Registered"},
+	KeyMacron:                 {"OBS_KEY_MACRON", "Macron"},
+	KeyDegree:                 {"OBS_KEY_DEGREE", "Degree"},
+	KeyPlusminus:              {"OBS_KEY_PLUSMINUS", "Plusminus"},
+	KeyTwosuperior:            {"OBS_KEY_TWOSUPERIOR", "Twosuperior"},
+	KeyThreesuperior:          {"OBS_KEY_THREESUPERIOR", "Threesuperior"},
+	KeyAcute:                  {"OBS_KEY_ACUTE", "Acute"},
+	KeyMu:                     {"OBS_KEY_MU", "Mu"},
+	KeyParagraph:              {"OBS_KEY_PARAGRAPH", "Paragraph"},
+	KeyPeriodcentered:         {"OBS_KEY_PERIODCENTERED", "Periodcentered"},
+	KeyCedilla:                {"OBS_KEY_CEDILLA", "Cedilla"},
+	KeyOnesuperior:            {"OBS_KEY_ONESUPERIOR", "Onesuperior"},
+	KeyMasculine:              {"OBS_KEY_MASCULINE", "Masculine"},
+	KeyGuillemotright:         {"OBS_KEY_GUILLEMOTRIGHT", "Guillemotright"},
+	KeyOnequarter:             {"OBS_KEY_ONEQUARTER", "Onequarter"},
+	KeyOnehalf:                {"OBS_KEY_ONEHALF", "Onehalf"},
+	KeyThreequarters:          {"OBS_KEY_THREEQUARTERS", "Threequarters"},
+	KeyQuestiondown:           {"OBS_KEY_QUESTIONDOWN", "Questiondown"},
+	KeyAgrave:                 {"OBS_KEY_AGRAVE", "Agrave"},
+	KeyAacute:                 {"OBS_KEY_AACUTE", "Aacute"},
+	KeyAcircumflex:            {"OBS_KEY_ACIRCUMFLEX", "Acircumflex"},
+	KeyAtilde:                 {"OBS_KEY_ATILDE", "Atilde"},
+	KeyAdiaeresis:             {"OBS_KEY_ADIAERESIS", "Adiaeresis"},
+	KeyAring:                  {"OBS_KEY_ARING", "Aring"},
+	KeyAe:                     {"OBS_KEY_AE", "Ae"},
+	KeyCcedilla:               {"OBS_KEY_CCEDILLA", "Ccedilla"},
+	KeyEgrave:                 {"OBS_KEY_EGRAVE", "Egrave"},
+	KeyEacute:                 {"OBS_KEY_EACUTE", "Eacute"},
+	KeyEcircumflex:            {"OBS_KEY_ECIRCUMFLEX", "Ecircumflex"},
+	KeyEdiaeresis:             {"OBS_KEY_EDIAERESIS", "Ediaeresis"},
+	KeyIgrave:                 {"OBS_KEY_IGRAVE", "Igrave"},
+	KeyIacute:                 {"OBS_KEY_IACUTE", "Iacute"},
+	KeyIcircumflex:            {"OBS_KEY_ICIRCUMFLEX", "Icircumflex"},
+	KeyIdiaeresis:             {"OBS_KEY_IDIAERESIS", "Idiaeresis"},
+	KeyEth:                    {"OBS_KEY_ETH", "Eth"},
+	KeyNtilde:                 {"OBS_KEY_NTILDE", "Ntilde"},
+	KeyOgrave:                 {"OBS_KEY_OGRAVE", "Ograve"},
+	KeyOacute:                 {"OBS_KEY_OACUTE", "Oacute"},
+	KeyOcircumflex:            {"OBS_KEY_OCIRCUMFLEX", "Ocircumflex"},
+	KeyOtilde:                 {"OBS_KEY_OTILDE", "Otilde"},
+	KeyOdiaeresis:             {"OBS_KEY_ODIAERESIS", "Odiaeresis"},
+	KeyMultiply:               {"OBS_KEY_MULTIPLY", "Multiply"},
+	KeyOoblique:               {"OBS_KEY_OOBLIQUE", "Ooblique"},
+	KeyUgrave:                 {"OBS_KEY_UGRAVE", "Ugrave"},
+	KeyUacute:                 {"OBS_KEY_UACUTE", "Uacute"},
+	KeyUcircumflex:            {"OBS_KEY_UCIRCUMFLEX", "Ucircumflex"},
+	KeyUdiaeresis:             {"OBS_KEY_UDIAERESIS", "Udiaeresis"},
+	KeyYacute:                 {"OBS_KEY_YACUTE", "Yacute"},
+	KeyThorn:                  {"OBS_KEY_THORN", "Thorn"},
+	KeySsharp:                 {"OBS_KEY_SSHARP", "Ssharp"},
+	KeyDivision:               {"OBS_KEY_DIVISION", "Division"},
+	KeyYdiaeresis:             {"OBS_KEY_YDIAERESIS", "Ydiaeresis"},
+	KeyMultiKey:               {"OBS_KEY_MULTI_KEY", "MultiKey"},
+	KeyCodeinput:              {"OBS_KEY_CODEINPUT", "Codeinput"},
+	KeySinglecandidate:        {"OBS_KEY_SINGLECANDIDATE", "Singlecandidate"},
+	KeyMultiplecandidate:      {"OBS_KEY_MULTIPLECANDIDATE", "Multiplecandidate"},
+	KeyPreviouscandidate:      {"OBS_KEY_PREVIOUSCANDIDATE", "Previouscandidate"},
+	KeyModeSwitch:             {"OBS_KEY_MODE_SWITCH", "ModeSwitch"},
+	KeyKanji:                  {"OBS_KEY_KANJI", "Kanji"},
+	KeyMuhenkan:               {"OBS_KEY_MUHENKAN", "Muhenkan"},
+	KeyHenkan:                 {"OBS_KEY_HENKAN", "Henkan"},
+	KeyRomaji:                 {"OBS_KEY_ROMAJI", "Romaji"},
+	KeyHiragana:               {"OBS_KEY_HIRAGANA", "Hiragana"},
+	KeyKatakana:               {"OBS_KEY_KATAKANA", "Katakana"},
+	KeyHiraganaKatakana:       {"OBS_KEY_HIRAGANA_KATAKANA", "HiraganaKatakana"},
+	KeyZenkaku:                {"OBS_KEY_ZENKAKU", "Zenkaku"},
+	KeyHankaku:                {"OBS_KEY_HANKAKU", "Hankaku"},
+	KeyZenkakuHankaku:         {"OBS_KEY_ZENKAKU_HANKAKU", "ZenkakuHankaku"},
+	KeyTouroku:                {"OBS_KEY_TOUROKU", "Touroku"},
+	KeyMassyo:                 {"OBS_KEY_MASSYO", "Massyo"},
+	KeyKanaLock:               {"OBS_KEY_KANA_LOCK", "KanaLock"},
+	KeyKanaShift:              {"OBS_KEY_KANA_SHIFT", "KanaShift"},
+	KeyEisuShift:              {"OBS_KEY_EISU_SHIFT", "EisuShift"},
+	KeyEisuToggle:             {"OBS_KEY_EISU_TOGGLE", "EisuToggle"},
+	KeyHangul:                 {"OBS_KEY_HANGUL", "Hangul"},
+	KeyHangulStart:            {"OBS_KEY_HANGUL_START", "HangulStart"},
+	KeyHangulEnd:              {"OBS_KEY_HANGUL_END", "HangulEnd"},
+	KeyHangulHanja:            {"OBS_KEY_HANGUL_HANJA", "HangulHanja"},
+	KeyHangulJamo:             {"OBS_KEY_HANGUL_JAMO", "HangulJamo"},
+	KeyHangulRomaja:           {"OBS_KEY_HANGUL_ROMAJA", "HangulRomaja"},
+	KeyHangulJeonja:           {"OBS_KEY_HANGUL_JEONJA", "HangulJeonja"},
+	KeyHangulBanja:            {"OBS_KEY_HANGUL_BANJA", "HangulBanja"},
+	KeyHangulPrehanja:         {"OBS_KEY_HANGUL_PREHANJA", "HangulPrehanja"},
+	KeyHangulPosthanja:        {"OBS_KEY_HANGUL_POSTHANJA", "HangulPosthanja"},
+	KeyHangulSpecial:          {"OBS_KEY_HANGUL_SPECIAL", "HangulSpecial"},
+	KeyDeadGrave:              {"OBS_KEY_DEAD_GRAVE", "DeadGrave"},
+	KeyDeadAcute:              {"OBS_KEY_DEAD_ACUTE", "DeadAcute"},
+	KeyDeadCircumflex:         {"OBS_KEY_DEAD_CIRCUMFLEX", "DeadCircumflex"},
+	KeyDeadTilde:              {"OBS_KEY_DEAD_TILDE", "DeadTilde"},
+	KeyDeadMacron:             {"OBS_KEY_DEAD_MACRON", "DeadMacron"},
+	KeyDeadBreve:              {"OBS_KEY_DEAD_BREVE", "DeadBreve"},
+	KeyDeadAbovedot:           {"OBS_KEY_DEAD_ABOVEDOT", "DeadAbovedot"},
+	KeyDeadDiaeresis:          {"OBS_KEY_DEAD_DIAERESIS", "DeadDiaeresis"},
+	KeyDeadAbovering:          {"OBS_KEY_DEAD_ABOVERING", "DeadAbovering"},
+	KeyDeadDoubleacute:        {"OBS_KEY_DEAD_DOUBLEACUTE", "DeadDoubleacute"},
+	KeyDeadCaron:              {"OBS_KEY_DEAD_CARON", "DeadCaron"},
+	KeyDeadCedilla:            {"OBS_KEY_DEAD_CEDILLA", "DeadCedilla"},
+	KeyDeadOgonek:             {"OBS_KEY_DEAD_OGONEK", "DeadOgonek"},
+	KeyDeadIota:               {"OBS_KEY_DEAD_IOTA", "DeadIota"},
+	KeyDeadVoicedSound:        {"OBS_KEY_DEAD_VOICED_SOUND", "DeadVoicedSound"},
+	KeyDeadSemivoicedSound:    {"OBS_KEY_DEAD_SEMIVOICED_SOUND", "DeadSemivoicedSound"},
+	KeyDeadBelowdot:           {"OBS_KEY_DEAD_BELOWDOT", "DeadBelowdot"},
+	KeyDeadHook:               {"OBS_KEY_DEAD_HOOK", "DeadHook"},
+	KeyDeadHorn:               {"OBS_KEY_DEAD_HORN", "DeadHorn"},
+	KeyBack:                   {"OBS_KEY_BACK", "Back"},
+	KeyForward:                {"OBS_KEY_FORWARD", "Forward"},
+	KeyStop:                   {"OBS_KEY_STOP", "Stop"},
+	KeyRefresh:                {"OBS_KEY_REFRESH", "Refresh"},
+	KeyVolumeDown:             {"OBS_KEY_VOLUMEDOWN", "VolumeDown"},
+	KeyVolumeMute:             {"OBS_KEY_VOLUMEMUTE", "VolumeMute"},
+	KeyVolumeUp:               {"OBS_KEY_VOLUMEUP", "VolumeUp"},
+	KeyBassboost:              {"OBS_KEY_BASSBOOST", "Bassboost"},
+	KeyBassup:                 {"OBS_KEY_BASSUP", "Bassup"},
+	KeyBassdown:               {"OBS_KEY_BASSDOWN", "Bassdown"},
+	KeyTrebleup:               {"OBS_KEY_TREBLEUP", "Trebleup"},
+	KeyTrebledown:             {"OBS_KEY_TREBLEDOWN", "Trebledown"},
+	KeyMediaPlay:              {"OBS_KEY_MEDIAPLAY", "MediaPlay"},
+	KeyMediaStop:              {"OBS_KEY_MEDIASTOP", "MediaStop"},
+	KeyMediaPrevious:          {"OBS_KEY_MEDIAPREVIOUS", "MediaPrevious"},
+	KeyMediaNext:              {"OBS_KEY_MEDIANEXT", "MediaNext"},
+	KeyMediaRecord:            {"OBS_KEY_MEDIARECORD", "MediaRecord"},
+	KeyMediaPause:             {"OBS_KEY_MEDIAPAUSE", "MediaPause"},
+	KeyMediaTogglePlayPause:   {"OBS_KEY_MEDIATOGGLEPLAYPAUSE", "MediaTogglePlayPause"},
+	KeyHomepage:               {"OBS_KEY_HOMEPAGE", "Homepage"},
+	KeyFavorites:              {"OBS_KEY_FAVORITES", "Favorites"},
+	KeySearch:                 {"OBS_KEY_SEARCH", "Search"},
+	KeyStandby:                {"OBS_KEY_STANDBY", "Standby"},
+	KeyOpenurl:                {"OBS_KEY_OPENURL", "Openurl"},
+	KeyLaunchmail:             {"OBS_KEY_LAUNCHMAIL", "Launchmail"},
+	KeyLaunchmedia:            {"OBS_KEY_LAUNCHMEDIA", "Launchmedia"},
+	KeyLaunch0:                {"OBS_KEY_LAUNCH0", "Launch0"},
+	KeyLaunch1:                {"OBS_KEY_LAUNCH1", "Launch1"},
+	KeyLaunch2:                {"OBS_KEY_LAUNCH2", "Launch2"},
+	KeyLaunch3:                {"OBS_KEY_LAUNCH3", "Launch3"},
+	KeyLaunch4:                {"OBS_KEY_LAUNCH4", "Launch4"},
+	KeyLaunch5:                {"OBS_KEY_LAUNCH5", "Launch5"},
+	KeyLaunch6:                {"OBS_KEY_LAUNCH6", "Launch6"},
+	KeyLaunch7:                {"OBS_KEY_LAUNCH7", "Launch7"},
+	KeyLaunch8:                {"OBS_KEY_LAUNCH8", "Launch8"},
+	KeyLaunch9:                {"OBS_KEY_LAUNCH9", "Launch9"},
+	KeyLauncha:                {"OBS_KEY_LAUNCHA", "Launcha"},
+	KeyLaunchb:                {"OBS_KEY_LAUNCHB", "Launchb"},
+	KeyLaunchc:                {"OBS_KEY_LAUNCHC", "Launchc"},
+	KeyLaunchd:                {"OBS_KEY_LAUNCHD", "Launchd"},
+	KeyLaunche:                {"OBS_KEY_LAUNCHE", "Launche"},
+	KeyLaunchf:                {"OBS_KEY_LAUNCHF", "Launchf"},
+	KeyLaunchg:                {"OBS_KEY_LAUNCHG", "Launchg"},
+	KeyLaunchh:                {"OBS_KEY_LAUNCHH", "Launchh"},
+	KeyMonbrightnessup:        {"OBS_KEY_MONBRIGHTNESSUP", "Monbrightnessup"},
+	KeyMonbrightnessdown:      {"OBS_KEY_MONBRIGHTNESSDOWN", "Monbrightnessdown"},
+	KeyKeyboardlightonoff:     {"OBS_KEY_KEYBOARDLIGHTONOFF", "Keyboardlightonoff"},
+	KeyKeyboardbrightnessup:   {"OBS_KEY_KEYBOARDBRIGHTNESSUP", "Keyboardbrightnessup"},
+	KeyKeyboardbrightnessdown: {"OBS_KEY_KEYBOARDBRIGHTNESSDOWN", "Keyboardbrightnessdown"},
+	KeyPoweroff:               {"OBS_KEY_POWEROFF", "Poweroff"},
+	KeyWakeup:                 {"OBS_KEY_WAKEUP", "Wakeup"},
+	KeyEject:                  {"OBS_KEY_EJECT", "Eject"},
+	KeyScreensaver:            {"OBS_KEY_SCREENSAVER", "Screensaver"},
+	KeyWww:                    {"OBS_KEY_WWW", "Www"},
+	KeyMemo:                   {"OBS_KEY_MEMO", "Memo"},
+	KeyLightbulb:              {"OBS_KEY_LIGHTBULB", "Lightbulb"},
+	KeyShop:                   {"OBS_KEY_SHOP", "Shop"},
+	KeyHistory:                {"OBS_KEY_HISTORY", "History"},
+	KeyAddfavorite:            {"OBS_KEY_ADDFAVORITE", "Addfavorite"},
+	KeyHotlinks:               {"OBS_KEY_HOTLINKS", "Hotlinks"},
+	KeyBrightnessadjust:       {"OBS_KEY_BRIGHTNESSADJUST", "Brightnessadjust"},
+	KeyFinance:                {"OBS_KEY_FINANCE", "Finance"},
+	KeyCommunity:              {"OBS_KEY_COMMUNITY", "Community"},
+	KeyAudiorewind:            {"OBS_KEY_AUDIOREWIND", "Audiorewind"},
+	KeyBackforward:            {"OBS_KEY_BACKFORWARD", "Backforward"},
+	KeyApplicationleft:        {"OBS_KEY_APPLICATIONLEFT", "Applicationleft"},
+	KeyApplicationright:       {"OBS_KEY_APPLICATIONRIGHT", "Applicationright"},
+	KeyBook:                   {"OBS_KEY_BOOK", "Book"},
+	KeyCd:                     {"OBS_KEY_CD", "Cd"},
+	KeyCalculator:             {"OBS_KEY_CALCULATOR", "Calculator"},
+	KeyTodolist:               {"OBS_KEY_TODOLIST", "Todolist"},
+	KeyCleargrab:              {"OBS_KEY_CLEARGRAB", "Cleargrab"},
+	KeyClose:                  {"OBS_KEY_CLOSE", "Close"},
+	KeyCopy:                   {"OBS_KEY_COPY", "Copy"},
+	KeyCut:                    {"OBS_KEY_CUT", "Cut"},
+	KeyDisplay:                {"OBS_KEY_DISPLAY", "Display"},
+	KeyDos:                    {"OBS_KEY_DOS", "Dos"},
+	KeyDocuments:              {"OBS_KEY_DOCUMENTS", "Documents"},
+	KeyExcel:                  {"OBS_KEY_EXCEL", "Excel"},
+	KeyExplorer:               {"OBS_KEY_EXPLORER", "Explorer"},
+	KeyGame:                   {"OBS_KEY_GAME", "Game"},
+	KeyGo:                     {"OBS_KEY_GO", "Go"},
+	KeyItouch:                 {"OBS_KEY_ITOUCH", "Itouch"},
+	KeyLogoff:                 {"OBS_KEY_LOGOFF", "Logoff"},
+	KeyMarket:                 {"OBS_KEY_MARKET", "Market"},
+	KeyMeeting:                {"OBS_KEY_MEETING", "Meeting"},
+	KeyMenukb:                 {"OBS_KEY_MENUKB", "Menukb"},
+	KeyMenupb:                 {"OBS_KEY_MENUPB", "Menupb"},
+	KeyMysites:                {"OBS_KEY_MYSITES", "Mysites"},
+	KeyNews:                   {"OBS_KEY_NEWS", "News"},
+	KeyOfficehome:             {"OBS_KEY_OFFICEHOME", "Officehome"},
+	KeyOption:                 {"OBS_KEY_OPTION", "Option"},
+	KeyPaste:                  {"OBS_KEY_PASTE", "Paste"},
+	KeyPhone:                  {"OBS_KEY_PHONE", "Phone"},
+	KeyCalendar:               {"OBS_KEY_CALENDAR", "Calendar"},
+	KeyReply:                  {"OBS_KEY_REPLY", "Reply"},
+	KeyReload:                 {"OBS_KEY_RELOAD", "Reload"},
+	KeyRotatewindows:          {"OBS_KEY_ROTATEWINDOWS", "Rotatewindows"},
+	KeyRotationpb:             {"OBS_KEY_ROTATIONPB", "Rotationpb"},
+	KeyRotationkb:             {"OBS_KEY_ROTATIONKB", "Rotationkb"},
+	KeySave:                   {"OBS_KEY_SAVE", "Save"},
+	KeySend:                   {"OBS_KEY_SEND", "Send"},
+	KeySpell:                  {"OBS_KEY_SPELL", "Spell"},
+	KeySplitscreen:            {"OBS_KEY_SPLITSCREEN", "Splitscreen"},
+	KeySupport:                {"OBS_KEY_SUPPORT", "Support"},
+	KeyTaskpane:               {"OBS_KEY_TASKPANE", "Taskpane"},
+	KeyTerminal:               {"OBS_KEY_TERMINAL", "Terminal"},
+	KeyTools:                  {"OBS_KEY_TOOLS", "Tools"},
+	KeyTravel:                 {"OBS_KEY_TRAVEL", "Travel"},
+	KeyVideo:                  {"OBS_KEY_VIDEO", "Video"},
+	KeyWord:                   {"OBS_KEY_WORD", "Word"},
+	KeyXfer:                   {"OBS_KEY_XFER", "Xfer"},
+	KeyZoomin:                 {"OBS_KEY_ZOOMIN", "Zoomin"},
+	KeyZoomout:                {"OBS_KEY_ZOOMOUT", "Zoomout"},
+	KeyAway:                   {"OBS_KEY_AWAY", "Away"},
+	KeyMessenger:              {"OBS_KEY_MESSENGER", "Messenger"},
+	KeyWebcam:                 {"OBS_KEY_WEBCAM", "Webcam"},
+	KeyMailforward:            {"OBS_KEY_MAILFORWARD", "Mailforward"},
+	KeyPictures:               {"OBS_KEY_PICTURES", "Pictures"},
+	KeyMusic:                  {"OBS_KEY_MUSIC", "Music"},
+	KeyBattery:                {"OBS_KEY_BATTERY", "Battery"},
+	KeyBluetooth:              {"OBS_KEY_BLUETOOTH", "Bluetooth"},
+	KeyWlan:                   {"OBS_KEY_WLAN", "Wlan"},
+	KeyUwb:                    {"OBS_KEY_UWB", "Uwb"},
+	KeyAudioforward:           {"OBS_KEY_AUDIOFORWARD", "Audioforward"},
+	KeyAudiorepeat:            {"OBS_KEY_AUDIOREPEAT", "Audiorepeat"},
+	KeyAudiorandomplay:        {"OBS_KEY_AUDIORANDOMPLAY", "Audiorandomplay"},
+	KeySubtitle:               {"OBS_KEY_SUBTITLE", "Subtitle"},
+	KeyAudiocycletrack:        {"OBS_KEY_AUDIOCYCLETRACK", "Audiocycletrack"},
+	KeyTime:                   {"OBS_KEY_TIME", "Time"},
+	KeyHibernate:              {"OBS_KEY_HIBERNATE", "Hibernate"},
+	KeyView:                   {"OBS_KEY_VIEW", "View"},
+	KeyTopmenu:                {"OBS_KEY_TOPMENU", "Topmenu"},
+	KeyPowerdown:              {"OBS_KEY_POWERDOWN", "Powerdown"},
+	KeySuspend:                {"OBS_KEY_SUSPEND", "Suspend"},
+	KeyContrastadjust:         {"OBS_KEY_CONTRASTADJUST", "Contrastadjust"},
+	KeyMedialast:              {"OBS_KEY_MEDIALAST", "Medialast"},
+	KeyCall:                   {"OBS_KEY_CALL", "Call"},
+	KeyCamera:                 {"OBS_KEY_CAMERA", "Camera"},
+	KeyCamerafocus:            {"OBS_KEY_CAMERAFOCUS", "Camerafocus"},
+	KeyContext1:               {"OBS_KEY_CONTEXT1", "Context1"},
+	KeyContext2:               {"OBS_KEY_CONTEXT2", "Context2"},
+	KeyContext3:               {"OBS_KEY_CONTEXT3", "Context3"},
+	KeyContext4:               {"OBS_KEY_CONTEXT4", "Context4"},
+	KeyFlip:                   {"OBS_KEY_FLIP", "Flip"},
+	KeyHangup:                 {"OBS_KEY_HANGUP", "Hangup"},
+	KeyNo:                     {"OBS_KEY_NO", "No"},
+	KeySelect:                 {"OBS_KEY_SELECT", "Select"},
+	KeyYes:                    {"OBS_KEY_YES", "Yes"},
+	KeyTogglecallhangup:       {"OBS_KEY_TOGGLECALLHANGUP", "Togglecallhangup"},
+	KeyVoicedial:              {"OBS_KEY_VOICEDIAL", "Voicedial"},
+	KeyLastnumberredial:       {"OBS_KEY_LASTNUMBERREDIAL", "Lastnumberredial"},
+	KeyExecute:                {"OBS_KEY_EXECUTE", "Execute"},
+	KeyPrinter:                {"OBS_KEY_PRINTER", "Printer"},
+	KeyPlay:                   {"OBS_KEY_PLAY", "Play"},
+	KeySleep:                  {"OBS_KEY_SLEEP", "Sleep"},
+	KeyZoom:                   {"OBS_KEY_ZOOM", "Zoom"},
+	KeyCancel:                 {"OBS_KEY_CANCEL", "Cancel"},
+	KeyMouse1:                 {"OBS_KEY_MOUSE1", "Mouse1"},
+	KeyMouse2:                 {"OBS_KEY_MOUSE2", "Mouse2"},
+	KeyMouse3:                 {"OBS_KEY_MOUSE3", "Mouse3"},
+	KeyMouse4:                 {"OBS_KEY_MOUSE4", "Mouse4"},
+	KeyMouse5:                 {"OBS_KEY_MOUSE5", "Mouse5"},
+	KeyMouse6:                 {"OBS_KEY_MOUSE6", "Mouse6"},
+	KeyMouse7:                 {"OBS_KEY_MOUSE7", "Mouse7"},
+	KeyMouse8:                 {"OBS_KEY_MOUSE8", "Mouse8"},
+	KeyMouse9:                 {"OBS_KEY_MOUSE9", "Mouse9"},
+	KeyMouse10:                {"OBS_KEY_MOUSE10", "Mouse10"},
+	KeyMouse11:                {"OBS_KEY_MOUSE11", "Mouse11"},
+	KeyMouse12:                {"OBS_KEY_MOUSE12", "Mouse12"},
+	KeyMouse13:                {"OBS_KEY_MOUSE13", "Mouse13"},
+	KeyMouse14:                {"OBS_KEY_MOUSE14", "Mouse14"},
+	KeyMouse15:                {"OBS_KEY_MOUSE15", "Mouse15"},
+	KeyMouse16:                {"OBS_KEY_MOUSE16", "Mouse16"},
+	KeyMouse17:                {"OBS_KEY_MOUSE17", "Mouse17"},
+	KeyMouse18:                {"OBS_KEY_MOUSE18", "Mouse18"},
+	KeyMouse19:                {"OBS_KEY_MOUSE19", "Mouse19"},
+	KeyMouse20:                {"OBS_KEY_MOUSE20", "Mouse20"},
+	KeyMouse21:                {"OBS_KEY_MOUSE21", "Mouse21"},
+	KeyMouse22:                {"OBS_KEY_MOUSE22", "Mouse22"},
+	KeyMouse23:                {"OBS_KEY_MOUSE23", "Mouse23"},
+	KeyMouse24:                {"OBS_KEY_MOUSE24", "Mouse24"},
+	KeyMouse25:                {"OBS_KEY_MOUSE25", "Mouse25"},
+	KeyMouse26:                {"OBS_KEY_MOUSE26", "Mouse26"},
+	KeyMouse27:                {"OBS_KEY_MOUSE27", "Mouse27"},
+	KeyMouse28:                {"OBS_KEY_MOUSE28", "Mouse28"},
+	KeyMouse29:                {"OBS_KEY_MOUSE29", "Mouse29"},
+	KeyBackslashRt102:         {"OBS_KEY_BACKSLASH_RT102", "BackslashRt102"},
+	KeyOpen:                   {"OBS_KEY_OPEN", "Open"},
+	KeyFind:                   {"OBS_KEY_FIND", "Find"},
+	KeyRedo:                   {"OBS_KEY_REDO", "Redo"},
+	KeyUndo:                   {"OBS_KEY_UNDO", "Undo"},
+	KeyFront:                  {"OBS_KEY_FRONT", "Front"},
+	KeyProps:                  {"OBS_KEY_PROPS", "Props"},
+}
@@ -2,6 +2,7 @@ package go_obs
 
 import (
 	"encoding/json"
+	"reflect"
 
 	"github.com/google/uuid"
 )
@@ -300,10 +301,10 @@ type DeleteSceneItemRequest struct {
 	// Name of the scene the scene item belongs to. Defaults to the current scene.
 	Scene string `json:"scene,omitempty"`
 	// Scene item to delete (required)
-	Item DeleteSceneItemItem `json:"item"`
+	Item ItemRef `json:"item"`
 }
 
-func (c *Client) DeleteSceneItem(Scene string, Item DeleteSceneItemItem) (*DeleteSceneItemResponse, error) {
+func (c *Client) DeleteSceneItem(Scene string, Item ItemRef) (*DeleteSceneItemResponse, error) {
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -386,10 +387,10 @@ type DuplicateSceneItemRequest struct {
 	// Name of the scene to create the item in. Defaults to the current scene.
 	ToScene string `json:"toScene,omitempty"`
 	// Scene Item to duplicate from the source scene (required)
-	Item DuplicateSceneItemItem `json:"item"`
+	Item ItemRef `json:"item"`
 }
 
-func (c *Client) DuplicateSceneItem(FromScene string, ToScene string, Item DuplicateSceneItemItem) (*DuplicateSceneItemResponse, error) {
+func (c *Client) DuplicateSceneItem(FromScene string, ToScene string, Item ItemRef) (*DuplicateSceneItemResponse, error) {
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -427,12 +428,7 @@ type DuplicateSceneItemResponse struct {
 	// Name of the scene where the new item was created
 	Scene string `json:"scene"`
 	// New item info
-	Item struct {
-		// New item ID
-		Id int `json:"id"`
-		// New item name
-		Name string `json:"name"`
-	} `json:"item"`
+	Item ItemRef `json:"item"`
 }
 
 // Enables Studio Mode.
@@ -1495,10 +1491,10 @@ type GetSceneItemPropertiesRequest struct {
 	SceneName string `json:"scene-name,omitempty"`
 	// Scene Item name (if this field is a string) or specification (if it is an
 	// object).
-	Item GetSceneItemPropertiesItem `json:"item"`
+	Item ItemRef `json:"item"`
 }
 
-func (c *Client) GetSceneItemProperties(SceneName string, Item GetSceneItemPropertiesItem) (*GetSceneItemPropertiesResponse, error) {
+func (c *Client) GetSceneItemProperties(SceneName string, Item ItemRef) (*GetSceneItemPropertiesResponse, error) {
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -1544,7 +1540,7 @@ type GetSceneItemPropertiesResponse struct {
 		Y float64 `json:"y"`
 		// The point on the source that the item is manipulated from. The sum of 1=Left
 		// or 2=Right, and 4=Top or 8=Bottom, or omit to center on that axis.
-		Alignment int `json:"alignment"`
+		Alignment Alignment `json:"alignment"`
 	} `json:"position"`
 	// The clockwise rotation of the item in degrees around the point of alignment.
 	Rotation float64 `json:"rotation"`
@@ -1585,7 +1581,7 @@ type GetSceneItemPropertiesResponse struct {
 		// "OBS_BOUNDS_SCALE_TO_HEIGHT", "OBS_BOUNDS_MAX_ONLY" or "OBS_BOUNDS_NONE".
 		Type string `json:"type"`
 		// Alignment of the bounding box.
-		Alignment int `json:"alignment"`
+		Alignment Alignment `json:"alignment"`
 		// Width of the bounding box.
 		X float64 `json:"x"`
 		// Height of the bounding box.
@@ -3589,10 +3585,10 @@ type ResetSceneItemRequest struct {
 	SceneName string `json:"scene-name,omitempty"`
 	// Scene Item name (if this field is a string) or specification (if it is an
 	// object).
-	Item ResetSceneItemItem `json:"item"`
+	Item ItemRef `json:"item"`
 }
 
-func (c *Client) ResetSceneItem(SceneName string, Item ResetSceneItemItem) (*ResetSceneItemResponse, error) {
+func (c *Client) ResetSceneItem(SceneName string, Item ItemRef) (*ResetSceneItemResponse, error) {
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -4500,7 +4496,10 @@ type SetSceneItemCropRequest struct {
 	Right int `json:"right"`
 }
 
-func (c *Client) SetSceneItemCrop(SceneName string, Item string, Top int, Bottom int, Left int, Right int) (*SetSceneItemCropResponse, error) {
+func (c *Client) SetSceneItemCrop(SceneName string, Item ItemRef, Top int, Bottom int, Left int, Right int) (*SetSceneItemCropResponse, error) {
+	if err := Item.requireName("SetSceneItemCrop"); err != nil {
+		return nil, err
+	}
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -4510,7 +4509,7 @@ func (c *Client) SetSceneItemCrop(SceneName string, Item string, Top int, Bottom
 			RequestType: "SetSceneItemCrop",
 		},
 		SceneName: SceneName,
-		Item:      Item,
+		Item:      Item.Name,
 		Top:       Top,
 		Bottom:    Bottom,
 		Left:      Left,
@@ -4556,7 +4555,10 @@ type SetSceneItemPositionRequest struct {
 	Y float64 `json:"y"`
 }
 
-func (c *Client) SetSceneItemPosition(SceneName string, Item string, X float64, Y float64) (*SetSceneItemPositionResponse, error) {
+func (c *Client) SetSceneItemPosition(SceneName string, Item ItemRef, X float64, Y float64) (*SetSceneItemPositionResponse, error) {
+	if err := Item.requireName("SetSceneItemPosition"); err != nil {
+		return nil, err
+	}
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -4566,7 +4568,7 @@ func (c *Client) SetSceneItemPosition(SceneName string, Item string, X float64,
 			RequestType: "SetSceneItemPosition",
 		},
 		SceneName: SceneName,
-		Item:      Item,
+		Item:      Item.Name,
 		X:         X,
 		Y:         Y,
 	}
@@ -4603,7 +4605,7 @@ type SetSceneItemPropertiesRequest struct {
 	SceneName string `json:"scene-name,omitempty"`
 	// Scene Item name (if this field is a string) or specification (if it is an
 	// object).
-	Item SetSceneItemPropertiesItem `json:"item"`
+	Item ItemRef `json:"item"`
 	// The new x position of the source.
 	Position SetSceneItemPropertiesPosition `json:"position"`
 	// The new clockwise rotation of the item in degrees.
@@ -4624,7 +4626,7 @@ type SetSceneItemPropertiesRequest struct {
 	Bounds SetSceneItemPropertiesBounds `json:"bounds"`
 }
 
-func (c *Client) SetSceneItemProperties(SceneName string, Item SetSceneItemPropertiesItem, Position SetSceneItemPropertiesPosition, Rotation *float64, Scale SetSceneItemPropertiesScale, Crop SetSceneItemPropertiesCrop, Visible *bool, Locked *bool, Bounds SetSceneItemPropertiesBounds) (*SetSceneItemPropertiesResponse, error) {
+func (c *Client) SetSceneItemProperties(SceneName string, Item ItemRef, Position SetSceneItemPropertiesPosition, Rotation *float64, Scale SetSceneItemPropertiesScale, Crop SetSceneItemPropertiesCrop, Visible *bool, Locked *bool, Bounds SetSceneItemPropertiesBounds) (*SetSceneItemPropertiesResponse, error) {
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -4681,7 +4683,10 @@ type SetSceneItemRenderRequest struct {
 	Render bool `json:"render"`
 }
 
-func (c *Client) SetSceneItemRender(SceneName string, Source string, Item *int, Render bool) (*SetSceneItemRenderResponse, error) {
+func (c *Client) SetSceneItemRender(SceneName string, Item ItemRef, Render bool) (*SetSceneItemRenderResponse, error) {
+	if err := Item.requireName("SetSceneItemRender"); err != nil {
+		return nil, err
+	}
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -4691,8 +4696,8 @@ func (c *Client) SetSceneItemRender(SceneName string, Source string, Item *int,
 			RequestType: "SetSceneItemRender",
 		},
 		SceneName: SceneName,
-		Source:    Source,
-		Item:      Item,
+		Source:    Item.Name,
+		Item:      Item.Id,
 		Render:    Render,
 	}
 
@@ -4737,7 +4742,10 @@ type SetSceneItemTransformRequest struct {
 	Rotation float64 `json:"rotation"`
 }
 
-func (c *Client) SetSceneItemTransform(SceneName string, Item string, XScale float64, YScale float64, Rotation float64) (*SetSceneItemTransformResponse, error) {
+func (c *Client) SetSceneItemTransform(SceneName string, Item ItemRef, XScale float64, YScale float64, Rotation float64) (*SetSceneItemTransformResponse, error) {
+	if err := Item.requireName("SetSceneItemTransform"); err != nil {
+		return nil, err
+	}
 	uuid := uuid.NewString()
 	errch := make(chan error)
 	defer close(errch)
@@ -4747,7 +4755,7 @@ func (c *Client) SetSceneItemTransform(SceneName string, Item string, XScale flo
 			RequestType: "SetSceneItemTransform",
 		},
 		SceneName: SceneName,
-		Item:      Item,
+		Item:      Item.Name,
 		XScale:    XScale,
 		YScale:    YScale,
 		Rotation:  Rotation,
@@ -6452,20 +6460,6 @@ type TriggerHotkeyBySequenceResponse struct {
 	resData
 }
 
-type DeleteSceneItemItem struct {
-	// Scene Item name (prefer `id`, including both is acceptable).
-	Name string `json:"name"`
-	// Scene Item ID.
-	Id int `json:"id"`
-}
-
-type DuplicateSceneItemItem struct {
-	// Scene Item name (prefer `id`, including both is acceptable).
-	Name string `json:"name"`
-	// Scene Item ID.
-	Id int `json:"id"`
-}
-
 type ExecuteBatchRequests struct {
 	// Request type. Eg. `GetVersion`.
 	RequestType string `json:"request-type"`
@@ -6474,13 +6468,6 @@ type ExecuteBatchRequests struct {
 	MessageId string `json:"message-id,omitempty"`
 }
 
-type GetSceneItemPropertiesItem struct {
-	// Scene Item name (if the `item` field is an object)
-	Name string `json:"name,omitempty"`
-	// Scene Item ID (if the `item` field is an object)
-	Id *int `json:"id,omitempty"`
-}
-
 type ReorderSceneItemsItems struct {
 	// Id of a specific scene item. Unique on a scene by scene basis.
 	Id *int `json:"id,omitempty"`
@@ -6489,27 +6476,13 @@ type ReorderSceneItemsItems struct {
 	Name string `json:"name,omitempty"`
 }
 
-type ResetSceneItemItem struct {
-	// Scene Item name (if the `item` field is an object)
-	Name string `json:"name,omitempty"`
-	// Scene Item ID (if the `item` field is an object)
-	Id *int `json:"id,omitempty"`
-}
-
-type SetSceneItemPropertiesItem struct {
-	// Scene Item name (if the `item` field is an object)
-	Name string `json:"name,omitempty"`
-	// Scene Item ID (if the `item` field is an object)
-	Id *int `json:"id,omitempty"`
-}
-
 type SetSceneItemPropertiesPosition struct {
 	// The new x position of the source.
 	X *float64 `json:"x,omitempty"`
 	// The new y position of the source.
 	Y *float64 `json:"y,omitempty"`
 	// The new alignment of the source.
-	Alignment *int `json:"alignment,omitempty"`
+	Alignment *Alignment `json:"alignment,omitempty"`
 }
 
 type SetSceneItemPropertiesScale struct {
@@ -6541,7 +6514,7 @@ type SetSceneItemPropertiesBounds struct {
 	// "OBS_BOUNDS_MAX_ONLY" or "OBS_BOUNDS_NONE".
 	Type string `json:"type,omitempty"`
 	// The new alignment of the bounding box. (0-2, 4-6, 8-10)
-	Alignment *int `json:"alignment,omitempty"`
+	Alignment *Alignment `json:"alignment,omitempty"`
 	// The new width of the bounding box.
 	X *float64 `json:"x,omitempty"`
 	// The new height of the bounding box.
@@ -6630,3 +6603,137 @@ type TriggerHotkeyBySequenceKeyModifiers struct {
 	// Trigger Command Key (Mac)
 	Command bool `json:"command"`
 }
+
+var requestTypes = map[string]reflect.Type{
+	"AddFilterToSource":             reflect.TypeOf(AddFilterToSourceRequest{}),
+	"AddSceneItem":                  reflect.TypeOf(AddSceneItemRequest{}),
+	"Authenticate":                  reflect.TypeOf(AuthenticateRequest{}),
+	"BroadcastCustomMessage":        reflect.TypeOf(BroadcastCustomMessageRequest{}),
+	"CreateScene":                   reflect.TypeOf(CreateSceneRequest{}),
+	"CreateSource":                  reflect.TypeOf(CreateSourceRequest{}),
+	"DeleteSceneItem":               reflect.TypeOf(DeleteSceneItemRequest{}),
+	"DisableStudioMode":             reflect.TypeOf(DisableStudioModeRequest{}),
+	"DuplicateSceneItem":            reflect.TypeOf(DuplicateSceneItemRequest{}),
+	"EnableStudioMode":              reflect.TypeOf(EnableStudioModeRequest{}),
+	"ExecuteBatch":                  reflect.TypeOf(ExecuteBatchRequest{}),
+	"GetAudioActive":                reflect.TypeOf(GetAudioActiveRequest{}),
+	"GetAudioMonitorType":           reflect.TypeOf(GetAudioMonitorTypeRequest{}),
+	"GetAudioTracks":                reflect.TypeOf(GetAudioTracksRequest{}),
+	"GetAuthRequired":               reflect.TypeOf(GetAuthRequiredRequest{}),
+	"GetBrowserSourceProperties":    reflect.TypeOf(GetBrowserSourcePropertiesRequest{}),
+	"GetCurrentProfile":             reflect.TypeOf(GetCurrentProfileRequest{}),
+	"GetCurrentScene":               reflect.TypeOf(GetCurrentSceneRequest{}),
+	"GetCurrentSceneCollection":     reflect.TypeOf(GetCurrentSceneCollectionRequest{}),
+	"GetCurrentTransition":          reflect.TypeOf(GetCurrentTransitionRequest{}),
+	"GetFilenameFormatting":         reflect.TypeOf(GetFilenameFormattingRequest{}),
+	"GetMediaDuration":              reflect.TypeOf(GetMediaDurationRequest{}),
+	"GetMediaSourcesList":           reflect.TypeOf(GetMediaSourcesListRequest{}),
+	"GetMediaState":                 reflect.TypeOf(GetMediaStateRequest{}),
+	"GetMediaTime":                  reflect.TypeOf(GetMediaTimeRequest{}),
+	"GetMute":                       reflect.TypeOf(GetMuteRequest{}),
+	"GetOutputInfo":                 reflect.TypeOf(GetOutputInfoRequest{}),
+	"GetPreviewScene":               reflect.TypeOf(GetPreviewSceneRequest{}),
+	"GetRecordingFolder":            reflect.TypeOf(GetRecordingFolderRequest{}),
+	"GetRecordingStatus":            reflect.TypeOf(GetRecordingStatusRequest{}),
+	"GetReplayBufferStatus":         reflect.TypeOf(GetReplayBufferStatusRequest{}),
+	"GetSceneItemList":              reflect.TypeOf(GetSceneItemListRequest{}),
+	"GetSceneItemProperties":        reflect.TypeOf(GetSceneItemPropertiesRequest{}),
+	"GetSceneList":                  reflect.TypeOf(GetSceneListRequest{}),
+	"GetSceneTransitionOverride":    reflect.TypeOf(GetSceneTransitionOverrideRequest{}),
+	"GetSourceActive":               reflect.TypeOf(GetSourceActiveRequest{}),
+	"GetSourceDefaultSettings":      reflect.TypeOf(GetSourceDefaultSettingsRequest{}),
+	"GetSourceFilterInfo":           reflect.TypeOf(GetSourceFilterInfoRequest{}),
+	"GetSourceFilters":              reflect.TypeOf(GetSourceFiltersRequest{}),
+	"GetSourceSettings":             reflect.TypeOf(GetSourceSettingsRequest{}),
+	"GetSourceTypesList":            reflect.TypeOf(GetSourceTypesListRequest{}),
+	"GetSourcesList":                reflect.TypeOf(GetSourcesListRequest{}),
+	"GetSpecialSources":             reflect.TypeOf(GetSpecialSourcesRequest{}),
+	"GetStats":                      reflect.TypeOf(GetStatsRequest{}),
+	"GetStreamSettings":             reflect.TypeOf(GetStreamSettingsRequest{}),
+	"GetStreamingStatus":            reflect.TypeOf(GetStreamingStatusRequest{}),
+	"GetStudioModeStatus":           reflect.TypeOf(GetStudioModeStatusRequest{}),
+	"GetSyncOffset":                 reflect.TypeOf(GetSyncOffsetRequest{}),
+	"GetTextGDIPlusProperties":      reflect.TypeOf(GetTextGDIPlusPropertiesRequest{}),
+	"GetTransitionDuration":         reflect.TypeOf(GetTransitionDurationRequest{}),
+	"GetTransitionList":             reflect.TypeOf(GetTransitionListRequest{}),
+	"GetTransitionPosition":         reflect.TypeOf(GetTransitionPositionRequest{}),
+	"GetTransitionSettings":         reflect.TypeOf(GetTransitionSettingsRequest{}),
+	"GetVersion":                    reflect.TypeOf(GetVersionRequest{}),
+	"GetVideoInfo":                  reflect.TypeOf(GetVideoInfoRequest{}),
+	"GetVirtualCamStatus":           reflect.TypeOf(GetVirtualCamStatusRequest{}),
+	"GetVolume":                     reflect.TypeOf(GetVolumeRequest{}),
+	"ListOutputs":                   reflect.TypeOf(ListOutputsRequest{}),
+	"ListProfiles":                  reflect.TypeOf(ListProfilesRequest{}),
+	"ListSceneCollections":          reflect.TypeOf(ListSceneCollectionsRequest{}),
+	"MoveSourceFilter":              reflect.TypeOf(MoveSourceFilterRequest{}),
+	"NextMedia":                     reflect.TypeOf(NextMediaRequest{}),
+	"OpenProjector":                 reflect.TypeOf(OpenProjectorRequest{}),
+	"PauseRecording":                reflect.TypeOf(PauseRecordingRequest{}),
+	"PlayPauseMedia":                reflect.TypeOf(PlayPauseMediaRequest{}),
+	"PreviousMedia":                 reflect.TypeOf(PreviousMediaRequest{}),
+	"RefreshBrowserSource":          reflect.TypeOf(RefreshBrowserSourceRequest{}),
+	"ReleaseTBar":                   reflect.TypeOf(ReleaseTBarRequest{}),
+	"RemoveFilterFromSource":        reflect.TypeOf(RemoveFilterFromSourceRequest{}),
+	"RemoveSceneTransitionOverride": reflect.TypeOf(RemoveSceneTransitionOverrideRequest{}),
+	"ReorderSceneItems":             reflect.TypeOf(ReorderSceneItemsRequest{}),
+	"ReorderSourceFilter":           reflect.TypeOf(ReorderSourceFilterRequest{}),
+	"ResetSceneItem":                reflect.TypeOf(ResetSceneItemRequest{}),
+	"RestartMedia":                  reflect.TypeOf(RestartMediaRequest{}),
+	"ResumeRecording":               reflect.TypeOf(ResumeRecordingRequest{}),
+	"SaveReplayBuffer":              reflect.TypeOf(SaveReplayBufferRequest{}),
+	"SaveStreamSettings":            reflect.TypeOf(SaveStreamSettingsRequest{}),
+	"ScrubMedia":                    reflect.TypeOf(ScrubMediaRequest{}),
+	"SendCaptions":                  reflect.TypeOf(SendCaptionsRequest{}),
+	"SetAudioMonitorType":           reflect.TypeOf(SetAudioMonitorTypeRequest{}),
+	"SetAudioTracks":                reflect.TypeOf(SetAudioTracksRequest{}),
+	"SetBrowserSourceProperties":    reflect.TypeOf(SetBrowserSourcePropertiesRequest{}),
+	"SetCurrentProfile":             reflect.TypeOf(SetCurrentProfileRequest{}),
+	"SetCurrentScene":               reflect.TypeOf(SetCurrentSceneRequest{}),
+	"SetCurrentSceneCollection":     reflect.TypeOf(SetCurrentSceneCollectionRequest{}),
+	"SetCurrentTransition":          reflect.TypeOf(SetCurrentTransitionRequest{}),
+	"SetFilenameFormatting":         reflect.TypeOf(SetFilenameFormattingRequest{}),
+	"SetHeartbeat":                  reflect.TypeOf(SetHeartbeatRequest{}),
+	"SetMediaTime":                  reflect.TypeOf(SetMediaTimeRequest{}),
+	"SetMute":                       reflect.TypeOf(SetMuteRequest{}),
+	"SetPreviewScene":               reflect.TypeOf(SetPreviewSceneRequest{}),
+	"SetRecordingFolder":            reflect.TypeOf(SetRecordingFolderRequest{}),
+	"SetSceneItemCrop":              reflect.TypeOf(SetSceneItemCropRequest{}),
+	"SetSceneItemPosition":          reflect.TypeOf(SetSceneItemPositionRequest{}),
+	"SetSceneItemProperties":        reflect.TypeOf(SetSceneItemPropertiesRequest{}),
+	"SetSceneItemRender":            reflect.TypeOf(SetSceneItemRenderRequest{}),
+	"SetSceneItemTransform":         reflect.TypeOf(SetSceneItemTransformRequest{}),
+	"SetSceneTransitionOverride":    reflect.TypeOf(SetSceneTransitionOverrideRequest{}),
+	"SetSourceFilterSettings":       reflect.TypeOf(SetSourceFilterSettingsRequest{}),
+	"SetSourceFilterVisibility":     reflect.TypeOf(SetSourceFilterVisibilityRequest{}),
+	"SetSourceName":                 reflect.TypeOf(SetSourceNameRequest{}),
+	"SetSourceSettings":             reflect.TypeOf(SetSourceSettingsRequest{}),
+	"SetStreamSettings":             reflect.TypeOf(SetStreamSettingsRequest{}),
+	"SetSyncOffset":                 reflect.TypeOf(SetSyncOffsetRequest{}),
+	"SetTBarPosition":               reflect.TypeOf(SetTBarPositionRequest{}),
+	"SetTextGDIPlusProperties":      reflect.TypeOf(SetTextGDIPlusPropertiesRequest{}),
+	"SetTransitionDuration":         reflect.TypeOf(SetTransitionDurationRequest{}),
+	"SetTransitionSettings":         reflect.TypeOf(SetTransitionSettingsRequest{}),
+	"SetVolume":                     reflect.TypeOf(SetVolumeRequest{}),
+	"Sleep":                         reflect.TypeOf(SleepRequest{}),
+	"StartOutput":                   reflect.TypeOf(StartOutputRequest{}),
+	"StartRecording":                reflect.TypeOf(StartRecordingRequest{}),
+	"StartReplayBuffer":             reflect.TypeOf(StartReplayBufferRequest{}),
+	"StartStopRecording":            reflect.TypeOf(StartStopRecordingRequest{}),
+	"StartStopReplayBuffer":         reflect.TypeOf(StartStopReplayBufferRequest{}),
+	"StartStopStreaming":            reflect.TypeOf(StartStopStreamingRequest{}),
+	"StartStopVirtualCam":           reflect.TypeOf(StartStopVirtualCamRequest{}),
+	"StartStreaming":                reflect.TypeOf(StartStreamingRequest{}),
+	"StartVirtualCam":               reflect.TypeOf(StartVirtualCamRequest{}),
+	"StopMedia":                     reflect.TypeOf(StopMediaRequest{}),
+	"StopOutput":                    reflect.TypeOf(StopOutputRequest{}),
+	"StopRecording":                 reflect.TypeOf(StopRecordingRequest{}),
+	"StopReplayBuffer":              reflect.TypeOf(StopReplayBufferRequest{}),
+	"StopStreaming":                 reflect.TypeOf(StopStreamingRequest{}),
+	"StopVirtualCam":                reflect.TypeOf(StopVirtualCamRequest{}),
+	"TakeSourceScreenshot":          reflect.TypeOf(TakeSourceScreenshotRequest{}),
+	"ToggleMute":                    reflect.TypeOf(ToggleMuteRequest{}),
+	"ToggleStudioMode":              reflect.TypeOf(ToggleStudioModeRequest{}),
+	"TransitionToProgram":           reflect.TypeOf(TransitionToProgramRequest{}),
+	"TriggerHotkeyByName":           reflect.TypeOf(TriggerHotkeyByNameRequest{}),
+	"TriggerHotkeyBySequence":       reflect.TypeOf(TriggerHotkeyBySequenceRequest{}),
+}
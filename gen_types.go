@@ -2,33 +2,33 @@ package go_obs
 
 // An OBS Scene Item.
 type SceneItem struct {
-	Cy float64
-	Cx float64
+	Cy float64 `json:"cy"`
+	Cx float64 `json:"cx"`
 	// The point on the source that the item is manipulated from. The sum of 1=Left
 	// or 2=Right, and 4=Top or 8=Bottom, or omit to center on that axis.
-	Alignment float64
+	Alignment Alignment `json:"alignment"`
 	// The name of this Scene Item.
-	Name string
+	Name string `json:"name"`
 	// Scene item ID
-	Id int
+	Id int `json:"id"`
 	// Whether or not this Scene Item is set to "visible".
-	Render bool
+	Render bool `json:"render"`
 	// Whether or not this Scene Item is muted.
-	Muted bool
+	Muted bool `json:"muted"`
 	// Whether or not this Scene Item is locked and can't be moved around
-	Locked   bool
-	SourceCx float64
-	SourceCy float64
+	Locked   bool    `json:"locked"`
+	SourceCx float64 `json:"source_cx"`
+	SourceCy float64 `json:"source_cy"`
 	// Source type. Value is one of the following: "input", "filter", "transition",
 	// "scene" or "unknown"
-	Type   string
-	Volume float64
-	X      float64
-	Y      float64
+	Type   string  `json:"type"`
+	Volume float64 `json:"volume"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
 	// Name of the item's parent (if this item belongs to a group)
-	ParentGroupName string
+	ParentGroupName string `json:"parentGroupName,omitempty"`
 	// List of children (if this item is a group)
-	GroupChildren []SceneItem
+	GroupChildren []SceneItem `json:"groupChildren,omitempty"`
 }
 
 type SceneItemTransform struct {
@@ -39,11 +39,11 @@ type SceneItemTransform struct {
 		// The y position of the scene item from the top.
 		Y float64 `json:"y"`
 		// The point on the scene item that the item is manipulated from.
-		Alignment int `json:"alignment"`
-	}
+		Alignment Alignment `json:"alignment"`
+	} `json:"position"`
 	// The clockwise rotation of the scene item in degrees around the point of
 	// alignment.
-	Rotation float64
+	Rotation float64 `json:"rotation"`
 	// The x-scale factor of the scene item.
 	Scale struct {
 		// The x-scale factor of the scene item.
@@ -54,7 +54,7 @@ type SceneItemTransform struct {
 		// "OBS_SCALE_POINT", "OBS_SCALE_BICUBIC", "OBS_SCALE_BILINEAR",
 		// "OBS_SCALE_LANCZOS" or "OBS_SCALE_AREA".
 		Filter string `json:"filter"`
-	}
+	} `json:"scale"`
 	// The number of pixels cropped off the top of the scene item before scaling.
 	Crop struct {
 		// The number of pixels cropped off the top of the scene item before scaling.
@@ -65,11 +65,11 @@ type SceneItemTransform struct {
 		Bottom int `json:"bottom"`
 		// The number of pixels cropped off the left of the scene item before scaling.
 		Left int `json:"left"`
-	}
+	} `json:"crop"`
 	// If the scene item is visible.
-	Visible bool
+	Visible bool `json:"visible"`
 	// If the scene item is locked in position.
-	Locked bool
+	Locked bool `json:"locked"`
 	// Type of bounding box. Can be "OBS_BOUNDS_STRETCH", "OBS_BOUNDS_SCALE_INNER",
 	// "OBS_BOUNDS_SCALE_OUTER", "OBS_BOUNDS_SCALE_TO_WIDTH",
 	// "OBS_BOUNDS_SCALE_TO_HEIGHT", "OBS_BOUNDS_MAX_ONLY" or "OBS_BOUNDS_NONE".
@@ -79,58 +79,58 @@ type SceneItemTransform struct {
 		// "OBS_BOUNDS_SCALE_TO_HEIGHT", "OBS_BOUNDS_MAX_ONLY" or "OBS_BOUNDS_NONE".
 		Type string `json:"type"`
 		// Alignment of the bounding box.
-		Alignment int `json:"alignment"`
+		Alignment Alignment `json:"alignment"`
 		// Width of the bounding box.
 		X float64 `json:"x"`
 		// Height of the bounding box.
 		Y float64 `json:"y"`
-	}
+	} `json:"bounds"`
 	// Base width (without scaling) of the source
-	SourceWidth int
+	SourceWidth int `json:"sourceWidth"`
 	// Base source (without scaling) of the source
-	SourceHeight int
+	SourceHeight int `json:"sourceHeight"`
 	// Scene item width (base source width multiplied by the horizontal scaling
 	// factor)
-	Width float64
+	Width float64 `json:"width"`
 	// Scene item height (base source height multiplied by the vertical scaling
 	// factor)
-	Height float64
+	Height float64 `json:"height"`
 	// Name of the item's parent (if this item belongs to a group)
-	ParentGroupName string
+	ParentGroupName string `json:"parentGroupName,omitempty"`
 	// List of children (if this item is a group)
-	GroupChildren []SceneItemTransform
+	GroupChildren []SceneItemTransform `json:"groupChildren,omitempty"`
 }
 
 type OBSStats struct {
 	// Current framerate.
-	Fps float64
+	Fps float64 `json:"fps"`
 	// Number of frames rendered
-	RenderTotalFrames int
+	RenderTotalFrames int `json:"render-total-frames"`
 	// Number of frames missed due to rendering lag
-	RenderMissedFrames int
+	RenderMissedFrames int `json:"render-missed-frames"`
 	// Number of frames outputted
-	OutputTotalFrames int
+	OutputTotalFrames int `json:"output-total-frames"`
 	// Number of frames skipped due to encoding lag
-	OutputSkippedFrames int
+	OutputSkippedFrames int `json:"output-skipped-frames"`
 	// Average frame render time (in milliseconds)
-	AverageFrameTime float64
+	AverageFrameTime float64 `json:"average-frame-time"`
 	// Current CPU usage (percentage)
-	CpuUsage float64
+	CpuUsage float64 `json:"cpu-usage"`
 	// Current RAM usage (in megabytes)
-	MemoryUsage float64
+	MemoryUsage float64 `json:"memory-usage"`
 	// Free recording disk space (in megabytes)
-	FreeDiskSpace float64
+	FreeDiskSpace float64 `json:"free-disk-space"`
 }
 
 type Output struct {
 	// Output name
-	Name string
+	Name string `json:"name"`
 	// Output type/kind
-	Type string
+	Type string `json:"type"`
 	// Video output width
-	Width int
+	Width int `json:"width"`
 	// Video output height
-	Height int
+	Height int `json:"height"`
 	// Output flags
 	Flags struct {
 		// Raw flags value
@@ -145,31 +145,31 @@ type Output struct {
 		MultiTrack bool `json:"multiTrack"`
 		// Output uses a service
 		Service bool `json:"service"`
-	}
+	} `json:"flags"`
 	// Output settings
-	Settings interface{}
+	Settings interface{} `json:"settings"`
 	// Output status (active or not)
-	Active bool
+	Active bool `json:"active"`
 	// Output reconnection status (reconnecting or not)
-	Reconnecting bool
+	Reconnecting bool `json:"reconnecting"`
 	// Output congestion
-	Congestion float64
+	Congestion float64 `json:"congestion"`
 	// Number of frames sent
-	TotalFrames int
+	TotalFrames int `json:"totalFrames"`
 	// Number of frames dropped
-	DroppedFrames int
+	DroppedFrames int `json:"droppedFrames"`
 	// Total bytes sent
-	TotalBytes int
+	TotalBytes int `json:"totalBytes"`
 }
 
 type ScenesCollection struct {
 	// Name of the scene collection
-	ScName string
+	ScName string `json:"sc-name"`
 }
 
 type Scene struct {
 	// Name of the currently active scene.
-	Name string
+	Name string `json:"name"`
 	// Ordered list of the current scene's source items.
-	Sources []SceneItem
+	Sources []SceneItem `json:"sources"`
 }
@@ -0,0 +1,345 @@
+package go_obs
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// StreamStatus events are sent every 2 seconds while streaming. If none has
+// arrived for this long, the stream is assumed to have stopped.
+const streamStatusTimeout = 5 * time.Second
+
+// StreamSample is a single measurement of stream and OBS health. Samples are
+// taken from StreamStatus events while streaming, and from GetStats
+// otherwise.
+type StreamSample struct {
+	// Time the sample was taken.
+	Time time.Time
+	// Whether the sample came from a StreamStatus event. The stream fields
+	// (KbitsPerSec to NumDroppedFrames) are only set if this is true.
+	Streaming bool
+	// Amount of data per second (in kilobits) transmitted by the stream
+	// encoder.
+	KbitsPerSec int
+	// Percentage of dropped frames.
+	Strain float64
+	// Total time since the stream started.
+	TotalStreamTime time.Duration
+	// Total number of frames transmitted since the stream started.
+	NumTotalFrames int
+	// Number of frames dropped by the encoder since the stream started.
+	NumDroppedFrames int
+	// Current framerate.
+	Fps float64
+	// Number of frames rendered.
+	RenderTotalFrames int
+	// Number of frames missed due to rendering lag.
+	RenderMissedFrames int
+	// Number of frames outputted.
+	OutputTotalFrames int
+	// Number of frames skipped due to encoding lag.
+	OutputSkippedFrames int
+	// Average frame time (in milliseconds).
+	AverageFrameTime float64
+	// Current CPU usage (percentage).
+	CpuUsage float64
+	// Current RAM usage (in megabytes).
+	MemoryUsage float64
+	// Free recording disk space (in megabytes).
+	FreeDiskSpace float64
+}
+
+// Aggregate holds rolling statistics of a value over a window of samples.
+type Aggregate struct {
+	// Number of samples which had the value.
+	Count int
+	// Mean of the values.
+	Avg float64
+	// Smallest value.
+	Min float64
+	// Largest value.
+	Max float64
+	// Most recent value.
+	Last float64
+}
+
+func (a *Aggregate) add(v float64) {
+	if a.Count == 0 {
+		a.Min, a.Max = v, v
+	}
+	a.Avg += (v - a.Avg) / float64(a.Count+1)
+	a.Min = math.Min(a.Min, v)
+	a.Max = math.Max(a.Max, v)
+	a.Last = v
+	a.Count++
+}
+
+// Counter holds the change of a frame counter over a window of samples.
+// Counters which go backwards (eg. when a new stream starts) are treated as
+// having been reset to zero.
+type Counter struct {
+	// Increase of the counter over the window.
+	Delta int
+	// Increase per minute.
+	PerMinute float64
+}
+
+// HealthStats summarizes the samples within a window.
+type HealthStats struct {
+	// Number of samples.
+	Samples int
+	// Time between the first and last samples.
+	Span time.Duration
+
+	KbitsPerSec      Aggregate
+	Strain           Aggregate
+	Fps              Aggregate
+	AverageFrameTime Aggregate
+	CpuUsage         Aggregate
+	MemoryUsage      Aggregate
+	FreeDiskSpace    Aggregate
+
+	DroppedFrames       Counter
+	RenderMissedFrames  Counter
+	OutputSkippedFrames Counter
+}
+
+// Function SummarizeSamples computes rolling statistics over the given
+// samples, which must be in chronological order.
+func SummarizeSamples(samples []StreamSample) HealthStats {
+	stats := HealthStats{Samples: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+	stats.Span = samples[len(samples)-1].Time.Sub(samples[0].Time)
+
+	var dropped, missed, skipped counterDelta
+	for _, s := range samples {
+		if s.Streaming {
+			stats.KbitsPerSec.add(float64(s.KbitsPerSec))
+			stats.Strain.add(s.Strain)
+			dropped.add(s.NumDroppedFrames, s.Time)
+		}
+		stats.Fps.add(s.Fps)
+		stats.AverageFrameTime.add(s.AverageFrameTime)
+		stats.CpuUsage.add(s.CpuUsage)
+		stats.MemoryUsage.add(s.MemoryUsage)
+		stats.FreeDiskSpace.add(s.FreeDiskSpace)
+		missed.add(s.RenderMissedFrames, s.Time)
+		skipped.add(s.OutputSkippedFrames, s.Time)
+	}
+	stats.DroppedFrames = dropped.counter()
+	stats.RenderMissedFrames = missed.counter()
+	stats.OutputSkippedFrames = skipped.counter()
+	return stats
+}
+
+type counterDelta struct {
+	n           int
+	last        int
+	delta       int
+	first, prev time.Time
+}
+
+func (d *counterDelta) add(v int, t time.Time) {
+	if d.n == 0 {
+		d.first = t
+	} else if v >= d.last {
+		d.delta += v - d.last
+	} else {
+		d.delta += v
+	}
+	d.last, d.prev = v, t
+	d.n++
+}
+
+func (d *counterDelta) counter() Counter {
+	c := Counter{Delta: d.delta}
+	if span := d.prev.Sub(d.first); span > 0 {
+		c.PerMinute = float64(d.delta) / span.Minutes()
+	}
+	return c
+}
+
+// HealthCollector records a history of stream health samples in a ring
+// buffer. While streaming, samples come from StreamStatus events; otherwise
+// OBS is polled with GetStats.
+type HealthCollector struct {
+	c         *Client
+	mx        sync.RWMutex
+	samples   []StreamSample
+	head      int
+	count     int
+	lastEvent time.Time
+	interval  time.Duration
+	onSample  map[int]func(StreamSample)
+	nextId    int
+	remove    func()
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Function NewHealthCollector creates a health collector for the given
+// client, keeping up to size samples. When not streaming, GetStats is polled
+// every pollInterval; a pollInterval of zero disables polling.
+func NewHealthCollector(c *Client, size int, pollInterval time.Duration) *HealthCollector {
+	if size < 1 {
+		size = 1
+	}
+	h := &HealthCollector{
+		c:        c,
+		samples:  make([]StreamSample, size),
+		interval: pollInterval,
+		onSample: make(map[int]func(StreamSample)),
+		done:     make(chan struct{}),
+	}
+	h.remove = c.addListener("StreamStatus", func(event any) {
+		e := event.(*StreamStatusEvent)
+		now := time.Now()
+		h.mx.Lock()
+		h.lastEvent = now
+		h.mx.Unlock()
+		h.add(StreamSample{
+			Time:                now,
+			Streaming:           true,
+			KbitsPerSec:         e.KbitsPerSec,
+			Strain:              e.Strain,
+			TotalStreamTime:     time.Duration(e.TotalStreamTime) * time.Second,
+			NumTotalFrames:      e.NumTotalFrames,
+			NumDroppedFrames:    e.NumDroppedFrames,
+			Fps:                 e.Fps,
+			RenderTotalFrames:   e.RenderTotalFrames,
+			RenderMissedFrames:  e.RenderMissedFrames,
+			OutputTotalFrames:   e.OutputTotalFrames,
+			OutputSkippedFrames: e.OutputSkippedFrames,
+			AverageFrameTime:    e.AverageFrameTime,
+			CpuUsage:            e.CpuUsage,
+			MemoryUsage:         e.MemoryUsage,
+			FreeDiskSpace:       e.FreeDiskSpace,
+		})
+	})
+	if pollInterval > 0 {
+		go h.poll(pollInterval)
+	}
+	return h
+}
+
+// Function Close stops the collector from recording samples.
+func (h *HealthCollector) Close() {
+	h.closeOnce.Do(func() {
+		h.remove()
+		close(h.done)
+	})
+}
+
+func (h *HealthCollector) poll(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+		}
+
+		h.mx.RLock()
+		streaming := time.Since(h.lastEvent) < streamStatusTimeout
+		h.mx.RUnlock()
+		if streaming {
+			continue
+		}
+		res, err := h.c.GetStats()
+		if err != nil {
+			continue
+		}
+		h.add(StreamSample{
+			Time:                time.Now(),
+			Fps:                 res.Stats.Fps,
+			RenderTotalFrames:   res.Stats.RenderTotalFrames,
+			RenderMissedFrames:  res.Stats.RenderMissedFrames,
+			OutputTotalFrames:   res.Stats.OutputTotalFrames,
+			OutputSkippedFrames: res.Stats.OutputSkippedFrames,
+			AverageFrameTime:    res.Stats.AverageFrameTime,
+			CpuUsage:            res.Stats.CpuUsage,
+			MemoryUsage:         res.Stats.MemoryUsage,
+			FreeDiskSpace:       res.Stats.FreeDiskSpace,
+		})
+	}
+}
+
+func (h *HealthCollector) add(s StreamSample) {
+	h.mx.Lock()
+	h.samples[(h.head+h.count)%len(h.samples)] = s
+	if h.count < len(h.samples) {
+		h.count++
+	} else {
+		h.head = (h.head + 1) % len(h.samples)
+	}
+	fns := make([]func(StreamSample), 0, len(h.onSample))
+	for _, fn := range h.onSample {
+		fns = append(fns, fn)
+	}
+	h.mx.Unlock()
+
+	for _, fn := range fns {
+		fn(s)
+	}
+}
+
+// Function OnSample registers fn to be called with every new sample. The
+// returned function unregisters the callback.
+func (h *HealthCollector) OnSample(fn func(StreamSample)) func() {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	id := h.nextId
+	h.nextId++
+	h.onSample[id] = fn
+	return func() {
+		h.mx.Lock()
+		defer h.mx.Unlock()
+		delete(h.onSample, id)
+	}
+}
+
+// Function Latest returns the most recent sample.
+func (h *HealthCollector) Latest() (StreamSample, bool) {
+	h.mx.RLock()
+	defer h.mx.RUnlock()
+	if h.count == 0 {
+		return StreamSample{}, false
+	}
+	return h.samples[(h.head+h.count-1)%len(h.samples)], true
+}
+
+// Function stale returns whether a newer sample than s should have been
+// recorded by now: StreamStatus events have stopped arriving, or polling
+// has stopped.
+func (h *HealthCollector) stale(s StreamSample, now time.Time) bool {
+	if s.Streaming {
+		return now.Sub(s.Time) >= streamStatusTimeout
+	}
+	return now.Sub(s.Time) >= 2*h.interval
+}
+
+// Function Samples returns the samples taken within the given window, oldest
+// first. A window of zero returns every recorded sample.
+func (h *HealthCollector) Samples(window time.Duration) []StreamSample {
+	h.mx.RLock()
+	defer h.mx.RUnlock()
+	cutoff := time.Now().Add(-window)
+	out := make([]StreamSample, 0, h.count)
+	for i := 0; i < h.count; i++ {
+		s := h.samples[(h.head+i)%len(h.samples)]
+		if window == 0 || !s.Time.Before(cutoff) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Function Stats summarizes the samples taken within the given window. A
+// window of zero summarizes every recorded sample.
+func (h *HealthCollector) Stats(window time.Duration) HealthStats {
+	return SummarizeSamples(h.Samples(window))
+}
@@ -0,0 +1,40 @@
+package go_obs_test
+
+import (
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestSummarizeSamples(t *testing.T) {
+	start := time.Now()
+	samples := []obs.StreamSample{
+		{Time: start, Streaming: true, KbitsPerSec: 6000, Strain: 0, NumDroppedFrames: 10, Fps: 60, RenderMissedFrames: 5},
+		{Time: start.Add(30 * time.Second), Streaming: true, KbitsPerSec: 4000, Strain: 10, NumDroppedFrames: 40, Fps: 58, RenderMissedFrames: 7},
+		{Time: start.Add(60 * time.Second), Fps: 60, RenderMissedFrames: 8},
+		// A new stream resets the dropped frames counter.
+		{Time: start.Add(90 * time.Second), Streaming: true, KbitsPerSec: 5000, Strain: 2, NumDroppedFrames: 20, Fps: 62, RenderMissedFrames: 11},
+	}
+
+	stats := obs.SummarizeSamples(samples)
+	if stats.Samples != 4 || stats.Span != 90*time.Second {
+		t.Errorf("got %d samples over %s", stats.Samples, stats.Span)
+	}
+	if k := stats.KbitsPerSec; k.Count != 3 || k.Avg != 5000 || k.Min != 4000 || k.Max != 6000 || k.Last != 5000 {
+		t.Errorf("kbits: %+v", k)
+	}
+	if f := stats.Fps; f.Count != 4 || f.Avg != 60 || f.Min != 58 || f.Max != 62 {
+		t.Errorf("fps: %+v", f)
+	}
+	if d := stats.DroppedFrames; d.Delta != 50 || d.PerMinute != 100.0/3 {
+		t.Errorf("dropped: %+v", d)
+	}
+	if m := stats.RenderMissedFrames; m.Delta != 6 || m.PerMinute != 4 {
+		t.Errorf("render missed: %+v", m)
+	}
+
+	if stats := obs.SummarizeSamples(nil); stats.Samples != 0 || stats.Fps.Count != 0 {
+		t.Errorf("empty: %+v", stats)
+	}
+}
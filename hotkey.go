@@ -0,0 +1,164 @@
+package go_obs
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Key is an OBS key, as used by TriggerHotkeyBySequence. The keys are
+// generated from the obs-hotkeys.h header of libobs by internal/genkeys.
+type Key int
+
+// Alternative names accepted by ParseKey, in lower case.
+var keyAliases = map[string]Key{
+	"esc":         KeyEscape,
+	"del":         KeyDelete,
+	"ins":         KeyInsert,
+	"pgup":        KeyPageUp,
+	"pgdn":        KeyPageDown,
+	"pagedn":      KeyPageDown,
+	"prtsc":       KeyPrint,
+	"printscreen": KeyPrint,
+	"bksp":        KeyBackspace,
+	"caps":        KeyCapsLock,
+	"ctrl":        KeyControl,
+	"grave":       KeyQuoteLeft,
+	"backquote":   KeyQuoteLeft,
+	"quote":       KeyApostrophe,
+}
+
+var keysByName map[string]Key
+
+func init() {
+	keysByName = make(map[string]Key, 2*len(keyNames)+len(keyAliases))
+	for k, v := range keyNames {
+		keysByName[strings.ToLower(v.id)] = Key(k)
+		keysByName[strings.ToLower(v.name)] = Key(k)
+	}
+	for name, k := range keyAliases {
+		keysByName[name] = k
+	}
+}
+
+// Function String returns the OBS key ID (eg. `OBS_KEY_F13`).
+func (k Key) String() string {
+	if k < 0 || int(k) >= len(keyNames) {
+		return "Key(" + strconv.Itoa(int(k)) + ")"
+	}
+	return keyNames[k].id
+}
+
+// Function Name returns the human readable name of the key (eg. `F13`).
+func (k Key) Name() string {
+	if k < 0 || int(k) >= len(keyNames) {
+		return k.String()
+	}
+	return keyNames[k].name
+}
+
+// Function ParseKey parses a key from its OBS key ID (`OBS_KEY_F13`), its
+// name (`F13`, `PageUp`, `Num5`, `-`), or a common alias (`Esc`, `PgUp`),
+// ignoring case.
+func ParseKey(s string) (Key, error) {
+	if k, ok := keysByName[strings.ToLower(strings.TrimSpace(s))]; ok && k != KeyNone {
+		return k, nil
+	}
+	return KeyNone, errors.New("unknown key: " + s)
+}
+
+// KeyCombo is a key pressed together with modifiers.
+type KeyCombo struct {
+	Key     Key
+	Shift   bool
+	Alt     bool
+	Control bool
+	// The Command key on macOS.
+	Command bool
+}
+
+// Function ParseKeyCombo parses a key combination such as `Ctrl+Shift+F13`.
+// The modifiers are `Shift`, `Alt` (or `Option`), `Ctrl` (or `Control`) and
+// `Cmd` (or `Command`, `Super`, `Win`), ignoring case, and must come before
+// the key.
+func ParseKeyCombo(s string) (KeyCombo, error) {
+	var combo KeyCombo
+	parts := strings.Split(s, "+")
+	// The plus key leaves two empty parts at the end (eg. `Ctrl++`).
+	if n := len(parts); n >= 2 && strings.TrimSpace(parts[n-1]) == "" && strings.TrimSpace(parts[n-2]) == "" {
+		parts = append(parts[:n-2], "+")
+	}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if i == len(parts)-1 {
+			k, err := ParseKey(part)
+			if err != nil {
+				return KeyCombo{}, err
+			}
+			combo.Key = k
+			break
+		}
+		switch strings.ToLower(part) {
+		case "shift":
+			combo.Shift = true
+		case "alt", "option":
+			combo.Alt = true
+		case "ctrl", "control":
+			combo.Control = true
+		case "cmd", "command", "super", "win":
+			combo.Command = true
+		default:
+			return KeyCombo{}, errors.New("unknown modifier: " + part)
+		}
+	}
+	return combo, nil
+}
+
+// Function String returns the combination in the format accepted by
+// ParseKeyCombo.
+func (k KeyCombo) String() string {
+	var parts []string
+	if k.Control {
+		parts = append(parts, "Ctrl")
+	}
+	if k.Alt {
+		parts = append(parts, "Alt")
+	}
+	if k.Shift {
+		parts = append(parts, "Shift")
+	}
+	if k.Command {
+		parts = append(parts, "Cmd")
+	}
+	return strings.Join(append(parts, k.Key.Name()), "+")
+}
+
+// Function Modifiers returns the modifiers of the combination for
+// TriggerHotkeyBySequence.
+func (k KeyCombo) Modifiers() TriggerHotkeyBySequenceKeyModifiers {
+	return TriggerHotkeyBySequenceKeyModifiers{
+		Shift:   k.Shift,
+		Alt:     k.Alt,
+		Control: k.Control,
+		Command: k.Command,
+	}
+}
+
+// Function TriggerKeyCombo triggers the hotkeys bound to a key combination.
+func (c *Client) TriggerKeyCombo(combo KeyCombo) error {
+	if combo.Key == KeyNone {
+		return errors.New("no key in key combination")
+	}
+	_, err := c.TriggerHotkeyBySequence(combo.Key.String(), combo.Modifiers())
+	return err
+}
+
+// Function TriggerHotkey parses a key combination such as `Ctrl+Shift+F13`
+// and triggers the hotkeys bound to it.
+func (c *Client) TriggerHotkey(combo string) error {
+	k, err := ParseKeyCombo(combo)
+	if err != nil {
+		return err
+	}
+	return c.TriggerKeyCombo(k)
+}
@@ -0,0 +1,48 @@
+package go_obs_test
+
+import (
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestParseKeyCombo(t *testing.T) {
+	cases := map[string]obs.KeyCombo{
+		"Ctrl+Shift+F13":       {Key: obs.KeyF13, Control: true, Shift: true},
+		"alt + pgup":           {Key: obs.KeyPageUp, Alt: true},
+		"Cmd+OBS_KEY_NUM5":     {Key: obs.KeyNum5, Command: true},
+		"Control+Option+-":     {Key: obs.KeyMinus, Control: true, Alt: true},
+		"space":                {Key: obs.KeySpace},
+		"Shift+Mouse4":         {Key: obs.KeyMouse4, Shift: true},
+		"Win+Ctrl+Alt+Shift+a": {Key: obs.KeyA, Command: true, Control: true, Alt: true, Shift: true},
+		"Ctrl++":               {Key: obs.KeyPlus, Control: true},
+		"+":                    {Key: obs.KeyPlus},
+		"Alt+OBS_KEY_ALTGR":    {Key: obs.KeyAltGr, Alt: true},
+	}
+	for s, want := range cases {
+		got, err := obs.ParseKeyCombo(s)
+		if err != nil || got != want {
+			t.Errorf("%q: got %+v (%v), want %+v", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "Ctrl+", "Ctrl+++", "Ctrl+F36", "Hyper+A", "F13+Ctrl", "None"} {
+		if _, err := obs.ParseKeyCombo(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+
+	combo := obs.KeyCombo{Key: obs.KeyF13, Control: true, Shift: true}
+	if combo.String() != "Ctrl+Shift+F13" || combo.Key.String() != "OBS_KEY_F13" {
+		t.Errorf("got %s, %s", combo, combo.Key)
+	}
+	if obs.KeyBracketLeft.String() != "OBS_KEY_BRACKETLEFT" || obs.Key(9999).String() != "Key(9999)" {
+		t.Errorf("got %s, %s", obs.KeyBracketLeft, obs.Key(9999))
+	}
+	if k, err := obs.ParseKey("OBS_KEY_PLUS"); err != nil || k != obs.KeyPlus || k.Name() != "+" {
+		t.Errorf("plus: %v (%v)", k, err)
+	}
+	if combo := (obs.KeyCombo{Key: obs.KeyPlus, Control: true}); combo.String() != "Ctrl++" {
+		t.Errorf("got %s", combo)
+	}
+}
@@ -17,6 +17,30 @@ var typeMapping = map[string]string{
 	"object":  "interface{}",
 }
 
+// propertyTypeMapping overrides the type of any property with the given
+// (unqualified) name, for properties which have a richer Go type than the
+// protocol definition describes.
+var propertyTypeMapping = map[string]string{
+	"alignment": "Alignment",
+}
+
+// polymorphicProperties maps properties which may be given either as a
+// scalar or as an object (described by their dotted subproperties) to the
+// Go type which handles both forms.
+var polymorphicProperties = map[string]string{
+	"item": "ItemRef",
+}
+
+// itemNameRequests lists scene item requests which only accept an item by
+// name (with its ID, if any, in a separate field). Their functions still take
+// an ItemRef, and each listed parameter is filled from it.
+var itemNameRequests = map[string]map[string]string{
+	"SetSceneItemCrop":      {"Item": "Item.Name"},
+	"SetSceneItemPosition":  {"Item": "Item.Name"},
+	"SetSceneItemRender":    {"Source": "Item.Name", "Item": "Item.Id"},
+	"SetSceneItemTransform": {"Item": "Item.Name"},
+}
+
 // Function convert converts a JsonProtocol object into an instance of
 // the more easily usable Protocol type.
 func convert(j *JsonProtocol) Protocol {
@@ -82,6 +106,11 @@ func convertRequest(r JsonRequest) Request {
 	}
 	out.Parameters = convertProperties(r.Parameters)
 	out.Returns = convertProperties(r.Returns)
+	if values, ok := itemNameRequests[r.Name]; ok {
+		for i, p := range out.Parameters {
+			out.Parameters[i].Value = values[p.Name]
+		}
+	}
 	return out
 }
 
@@ -90,9 +119,14 @@ func convertProperties(props []JsonProperty) []Property {
 
 	// Check for embedded struct types.
 	embeds := make(map[string]StructType)
+	polymorphic := make(map[string]string)
 	for _, v := range props {
 		if strings.ContainsRune(v.Name, '.') {
 			parts := strings.Split(v.Name, ".")
+			if typ, ok := polymorphicProperties[parts[0]]; ok {
+				polymorphic[parts[0]] = typ
+				continue
+			}
 
 			// HACK: Handle the **single case** in the entire protocol of
 			// doubly-nested anonymous structs. Thanks, OBS websocket
@@ -192,7 +226,7 @@ func convertProperties(props []JsonProperty) []Property {
 			}
 
 			part := parts[len(parts)-1]
-			ct := convertType(v.Type)
+			ct := convertPropertyType(part, v.Type)
 			var jt string
 			if ct.optional {
 				jt = part + ",omitempty"
@@ -219,6 +253,21 @@ func convertProperties(props []JsonProperty) []Property {
 	// them when looping over every single one of their members as well.
 	written := make(map[string]struct{})
 	for _, v := range props {
+		// Write polymorphic properties with their dedicated type.
+		name := strings.Split(v.Name, ".")[0]
+		if typ, ok := polymorphic[name]; ok {
+			if _, ok := written[name]; !ok {
+				out = append(out, Property{
+					Name:    camelPascal(name),
+					JsonTag: name,
+					Docs:    v.Docs,
+					Type:    BasicType{name: typ},
+				})
+				written[name] = struct{}{}
+			}
+			continue
+		}
+
 		// Skip members of embedded types.
 		if strings.ContainsRune(v.Name, '.') {
 			parts := strings.Split(v.Name, ".")
@@ -260,7 +309,7 @@ func convertProperties(props []JsonProperty) []Property {
 			continue
 		}
 
-		ct := convertType(v.Type)
+		ct := convertPropertyType(v.Name, v.Type)
 		var jt string
 		if ct.optional {
 			jt = v.Name + ",omitempty"
@@ -271,13 +320,23 @@ func convertProperties(props []JsonProperty) []Property {
 			Name:    camelPascal(v.Name),
 			JsonTag: jt,
 			Docs:    v.Docs,
-			Type:    convertType(v.Type),
+			Type:    ct,
 		})
 	}
 
 	return out
 }
 
+// Function convertPropertyType converts the type of the named property,
+// applying any override from propertyTypeMapping.
+func convertPropertyType(name string, typ string) BasicType {
+	t := convertType(typ)
+	if val, ok := propertyTypeMapping[name]; ok {
+		t.name = val
+	}
+	return t
+}
+
 func convertType(typ string) BasicType {
 	t := strings.ToLower(typ)
 
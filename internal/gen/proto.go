@@ -31,4 +31,7 @@ type Property struct {
 	Docs    string
 	Type    Type
 	JsonTag string
+	// Expression which fills the parameter in request functions, instead of
+	// an argument of the same name.
+	Value string
 }
@@ -8,7 +8,11 @@ import (
 func writeEvents(events []Event) {
 	buf := bytes.Buffer{}
 	buf.WriteString(GO_OBS_PACKAGE)
-	buf.WriteString("import \"encoding/json\"\n\n")
+	buf.WriteString(`import (
+        "encoding/json"
+        "reflect"
+    )
+    `)
 	convBuf := bytes.Buffer{}
 	convBuf.WriteString("var eventConverters = map[string]func([]byte) any {\n")
 
@@ -47,7 +51,14 @@ func writeEvents(events []Event) {
         },
         `, e.Name, e.Name))
 	}
-	convBuf.WriteRune('}')
+	convBuf.WriteString("}\n\n")
 	buf.Write(convBuf.Bytes())
+
+	// Write event type lookup.
+	buf.WriteString("var eventTypes = map[string]reflect.Type{\n")
+	for _, e := range events {
+		buf.WriteString(fmt.Sprintf("%q: reflect.TypeOf(%sEvent{}),\n", e.Name, e.Name))
+	}
+	buf.WriteString("}\n")
 	fmtWrite("./gen_events.go", buf)
 }
@@ -10,6 +10,7 @@ func writeRequests(reqs []Request) {
 	buf.WriteString(GO_OBS_PACKAGE)
 	buf.WriteString(`import(
         "encoding/json"
+        "reflect"
 
         "github.com/google/uuid"
     )
@@ -52,7 +53,16 @@ func writeRequests(reqs []Request) {
 
 		// Write new request function.
 		buf.WriteString(fmt.Sprintf("func (c *Client) %s(", r.Name))
+		itemArg := false
 		for _, p := range r.Parameters {
+			if p.Value != "" {
+				// Parameters filled from an ItemRef share one argument.
+				if !itemArg {
+					buf.WriteString("Item ItemRef,")
+					itemArg = true
+				}
+				continue
+			}
 			var typeStr string
 			if _, ok := p.Type.(StructType); ok {
 				typeStr = r.Name + p.Name
@@ -65,6 +75,12 @@ func writeRequests(reqs []Request) {
 			buf.WriteString(fmt.Sprintf("%s %s,", p.Name, typeStr))
 		}
 		buf.WriteString(fmt.Sprintf(") (*%sResponse, error) {", r.Name))
+		if itemArg {
+			buf.WriteString(fmt.Sprintf(`
+                if err := Item.requireName("%s"); err != nil {
+                    return nil, err
+                }`, r.Name))
+		}
 		buf.WriteString(fmt.Sprintf(`
             uuid := uuid.NewString()
             errch := make(chan error)
@@ -76,7 +92,11 @@ func writeRequests(reqs []Request) {
                 },
         `, r.Name, r.Name))
 		for _, p := range r.Parameters {
-			buf.WriteString(fmt.Sprintf("%s: %s,\n", p.Name, p.Name))
+			value := p.Name
+			if p.Value != "" {
+				value = p.Value
+			}
+			buf.WriteString(fmt.Sprintf("%s: %s,\n", p.Name, value))
 		}
 		buf.WriteString("}\n")
 		buf.WriteString(fmt.Sprintf(`
@@ -122,5 +142,12 @@ func writeRequests(reqs []Request) {
 		buf.WriteString("}\n\n")
 	}
 	buf.Write(typebuf.Bytes())
+
+	// Write request type lookup.
+	buf.WriteString("var requestTypes = map[string]reflect.Type{\n")
+	for _, r := range reqs {
+		buf.WriteString(fmt.Sprintf("%q: reflect.TypeOf(%sRequest{}),\n", r.Name, r.Name))
+	}
+	buf.WriteString("}\n")
 	fmtWrite("./gen_requests.go", buf)
 }
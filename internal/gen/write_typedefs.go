@@ -14,10 +14,11 @@ func writeTypedefs(defs []Typedef) {
 		buf.WriteString(fmt.Sprintf("type %s struct {\n", t.Name))
 		for _, p := range t.Properties {
 			str := fmt.Sprintf(
-				"%s%s %s\n",
+				"%s%s %s `json:\"%s\"`\n",
 				wrapComment(p.Docs),
 				p.Name,
 				p.Type.String(),
+				p.JsonTag,
 			)
 			buf.WriteString(str)
 		}
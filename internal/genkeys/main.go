@@ -0,0 +1,180 @@
+// Command genkeys generates gen_keys.go, the table of OBS keys, from the
+// obs-hotkeys.h header of libobs:
+//
+//	go run ./internal/genkeys path/to/obs-studio/libobs/obs-hotkeys.h
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const FILE_PERMS = 0644
+
+// Matches OBS_HOTKEY(OBS_KEY_...) and OBS_MOUSE_BUTTON(OBS_KEY_...).
+var keyLine = regexp.MustCompile(`^\s*OBS_(?:HOTKEY|MOUSE_BUTTON)\((OBS_KEY_\w+)\)`)
+
+// Go names for keys whose IDs are more than one word, without the `Key`
+// prefix. Other keys are named by title casing each part of the ID.
+var goNames = map[string]string{
+	"SYSREQ":               "SysReq",
+	"PAGEUP":               "PageUp",
+	"PAGEDOWN":             "PageDown",
+	"ALTGR":                "AltGr",
+	"CAPSLOCK":             "CapsLock",
+	"NUMLOCK":              "NumLock",
+	"SCROLLLOCK":           "ScrollLock",
+	"QUOTEDBL":             "QuoteDbl",
+	"NUMBERSIGN":           "NumberSign",
+	"PARENLEFT":            "ParenLeft",
+	"PARENRIGHT":           "ParenRight",
+	"NUMEQUAL":             "NumEqual",
+	"NUMASTERISK":          "NumAsterisk",
+	"NUMPLUS":              "NumPlus",
+	"NUMCOMMA":             "NumComma",
+	"NUMMINUS":             "NumMinus",
+	"NUMPERIOD":            "NumPeriod",
+	"NUMSLASH":             "NumSlash",
+	"BRACKETLEFT":          "BracketLeft",
+	"BRACKETRIGHT":         "BracketRight",
+	"ASCIICIRCUM":          "AsciiCircum",
+	"QUOTELEFT":            "QuoteLeft",
+	"BRACELEFT":            "BraceLeft",
+	"BRACERIGHT":           "BraceRight",
+	"ASCIITILDE":           "AsciiTilde",
+	"VOLUMEDOWN":           "VolumeDown",
+	"VOLUMEMUTE":           "VolumeMute",
+	"VOLUMEUP":             "VolumeUp",
+	"MEDIAPLAY":            "MediaPlay",
+	"MEDIASTOP":            "MediaStop",
+	"MEDIAPREVIOUS":        "MediaPrevious",
+	"MEDIANEXT":            "MediaNext",
+	"MEDIARECORD":          "MediaRecord",
+	"MEDIAPAUSE":           "MediaPause",
+	"MEDIATOGGLEPLAYPAUSE": "MediaTogglePlayPause",
+}
+
+// Names of keys which type a symbol. Other keys are named after their Go
+// name.
+var symbols = map[string]string{
+	"EXCLAM":       "!",
+	"QUOTEDBL":     `"`,
+	"NUMBERSIGN":   "#",
+	"DOLLAR":       "$",
+	"PERCENT":      "%",
+	"AMPERSAND":    "&",
+	"APOSTROPHE":   "'",
+	"PARENLEFT":    "(",
+	"PARENRIGHT":   ")",
+	"ASTERISK":     "*",
+	"PLUS":         "+",
+	"COMMA":        ",",
+	"MINUS":        "-",
+	"PERIOD":       ".",
+	"SLASH":        "/",
+	"COLON":        ":",
+	"SEMICOLON":    ";",
+	"LESS":         "<",
+	"EQUAL":        "=",
+	"GREATER":      ">",
+	"QUESTION":     "?",
+	"AT":           "@",
+	"BRACKETLEFT":  "[",
+	"BACKSLASH":    `\`,
+	"BRACKETRIGHT": "]",
+	"ASCIICIRCUM":  "^",
+	"UNDERSCORE":   "_",
+	"QUOTELEFT":    "`",
+	"BRACELEFT":    "{",
+	"BAR":          "|",
+	"BRACERIGHT":   "}",
+	"ASCIITILDE":   "~",
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		die("Need a path to obs-hotkeys.h.")
+	}
+	f, err := os.Open(os.Args[1])
+	check(err)
+	defer f.Close()
+
+	// OBS_KEY_NONE is not in every version of the header, but is always the
+	// first key.
+	ids := []string{"OBS_KEY_NONE"}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := keyLine.FindStringSubmatch(scanner.Text())
+		if m != nil && m[1] != "OBS_KEY_NONE" {
+			ids = append(ids, m[1])
+		}
+	}
+	check(scanner.Err())
+	if len(ids) == 1 {
+		die("No keys found in " + os.Args[1])
+	}
+	writeKeys(ids)
+}
+
+// Function keyName returns the Go name (without the `Key` prefix) and the
+// human readable name of a key.
+func keyName(id string) (string, string) {
+	id = strings.TrimPrefix(id, "OBS_KEY_")
+	goName, ok := goNames[id]
+	if !ok {
+		for _, part := range strings.Split(id, "_") {
+			part = strings.ToLower(part)
+			goName += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	if name, ok := symbols[id]; ok {
+		return goName, name
+	}
+	return goName, goName
+}
+
+func writeKeys(ids []string) {
+	consts := bytes.Buffer{}
+	table := bytes.Buffer{}
+	seen := make(map[string]string)
+	for _, id := range ids {
+		goName, name := keyName(id)
+		if other, ok := seen[strings.ToLower(name)]; ok {
+			die(fmt.Sprintf("%s and %s are both named %q", other, id, name))
+		}
+		seen[strings.ToLower(name)] = id
+		consts.WriteString("Key" + goName + "\n")
+		table.WriteString(fmt.Sprintf("Key%s: {%q, %q},\n", goName, id, name))
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString("package go_obs\n\n")
+	buf.WriteString("// Keys, named after their OBS key IDs without the `OBS_KEY_` prefix.\n")
+	buf.WriteString("const (\n")
+	buf.WriteString(strings.Replace(consts.String(), "\n", " Key = iota\n", 1))
+	buf.WriteString(")\n\n")
+	buf.WriteString("var keyNames = [...]struct {\nid string\nname string\n}{\n")
+	buf.Write(table.Bytes())
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	check(err)
+	check(os.WriteFile("./gen_keys.go", src, FILE_PERMS))
+}
+
+// Function check exits the program if the provided error is not nil.
+func check(err error) {
+	if err != nil {
+		die(err)
+	}
+}
+
+// Function die exits the program with the given error message.
+func die(msg any) {
+	panic(fmt.Sprint(msg))
+}
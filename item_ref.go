@@ -0,0 +1,86 @@
+package go_obs
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ItemRef refers to a scene item by its name, its ID, or both. It marshals
+// to a `{name, id}` object, which every request taking an item as an object
+// accepts, and unmarshals from either that object or a plain name. Requests
+// which only accept an item by name (eg. SetSceneItemCrop) send its Name,
+// and fail if it is empty.
+type ItemRef struct {
+	// Scene item name.
+	Name string
+	// Scene item ID. Preferred over the name when set.
+	Id *int
+}
+
+// Function ItemByName returns a reference to the scene item with the given
+// name.
+func ItemByName(name string) ItemRef {
+	return ItemRef{Name: name}
+}
+
+// Function ItemById returns a reference to the scene item with the given ID.
+func ItemById(id int) ItemRef {
+	return ItemRef{Id: &id}
+}
+
+// Function ItemByNameAndId returns a reference to the scene item with the
+// given name and ID.
+func ItemByNameAndId(name string, id int) ItemRef {
+	return ItemRef{Name: name, Id: &id}
+}
+
+// Function ReorderItem converts the reference into an item for
+// ReorderSceneItems.
+func (r ItemRef) ReorderItem() ReorderSceneItemsItems {
+	return ReorderSceneItemsItems{Id: r.Id, Name: r.Name}
+}
+
+// Function String returns the item name, or its ID if it has no name.
+func (r ItemRef) String() string {
+	if r.Name == "" && r.Id != nil {
+		return "#" + strconv.Itoa(*r.Id)
+	}
+	return r.Name
+}
+
+// Function requireName returns an error if the reference has no name, for
+// requests which only accept an item by name.
+func (r ItemRef) requireName(requestType string) error {
+	if r.Name == "" {
+		return errors.New(requestType + " requires a scene item name")
+	}
+	return nil
+}
+
+func (r ItemRef) MarshalJSON() ([]byte, error) {
+	if r.Name == "" && r.Id == nil {
+		return nil, errors.New("empty scene item reference")
+	}
+	return json.Marshal(struct {
+		Name string `json:"name,omitempty"`
+		Id   *int   `json:"id,omitempty"`
+	}{r.Name, r.Id})
+}
+
+func (r *ItemRef) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*r = ItemRef{Name: name}
+		return nil
+	}
+	obj := struct {
+		Name string `json:"name"`
+		Id   *int   `json:"id"`
+	}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*r = ItemRef{Name: obj.Name, Id: obj.Id}
+	return nil
+}
@@ -0,0 +1,94 @@
+package go_obs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestItemRefMarshal(t *testing.T) {
+	tests := []struct {
+		ref  obs.ItemRef
+		want string
+	}{
+		{obs.ItemByName("Camera"), `{"name":"Camera"}`},
+		{obs.ItemById(0), `{"id":0}`},
+		{obs.ItemByNameAndId("Camera", 7), `{"name":"Camera","id":7}`},
+	}
+	for _, tt := range tests {
+		out, err := json.Marshal(tt.ref)
+		if err != nil || string(out) != tt.want {
+			t.Errorf("%v: got %s (%v), want %s", tt.ref, out, err, tt.want)
+		}
+	}
+	if _, err := json.Marshal(obs.ItemRef{}); err == nil {
+		t.Error("empty reference marshalled")
+	}
+}
+
+func TestItemRefUnmarshal(t *testing.T) {
+	var ref obs.ItemRef
+	if err := json.Unmarshal([]byte(`"Camera"`), &ref); err != nil {
+		t.Fatal(err)
+	}
+	if ref.Name != "Camera" || ref.Id != nil {
+		t.Errorf("name: %+v", ref)
+	}
+
+	res := obs.DuplicateSceneItemResponse{}
+	if err := json.Unmarshal([]byte(`{"scene":"Main","item":{"name":"Camera","id":12}}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Item.Name != "Camera" || res.Item.Id == nil || *res.Item.Id != 12 {
+		t.Errorf("object: %+v", res.Item)
+	}
+
+	// Round trip through the object form.
+	out, err := json.Marshal(res.Item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back obs.ItemRef
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Name != "Camera" || back.Id == nil || *back.Id != 12 {
+		t.Errorf("round trip: %+v", back)
+	}
+}
+
+func TestItemRefRequiresName(t *testing.T) {
+	f := newFakeOBS(t)
+	f.reply("SetSceneItemCrop", nil)
+	f.reply("SetSceneItemPosition", nil)
+	f.reply("SetSceneItemTransform", nil)
+	f.reply("SetSceneItemRender", nil)
+	c := f.connect()
+
+	// These requests only accept an item by name, so an ID alone fails
+	// without reaching OBS.
+	for _, ref := range []obs.ItemRef{obs.ItemById(3), {}} {
+		calls := map[string]error{}
+		_, calls["SetSceneItemCrop"] = c.SetSceneItemCrop("Main", ref, 0, 0, 0, 0)
+		_, calls["SetSceneItemPosition"] = c.SetSceneItemPosition("Main", ref, 0, 0)
+		_, calls["SetSceneItemTransform"] = c.SetSceneItemTransform("Main", ref, 1, 1, 0)
+		_, calls["SetSceneItemRender"] = c.SetSceneItemRender("Main", ref, true)
+		for name, err := range calls {
+			if err == nil {
+				t.Errorf("%s with %+v succeeded", name, ref)
+			}
+			if n := len(f.sent(name)); n != 0 {
+				t.Errorf("%s sent %d times", name, n)
+			}
+		}
+	}
+
+	if _, err := c.SetSceneItemRender("Main", obs.ItemByNameAndId("Camera", 3), true); err != nil {
+		t.Fatal(err)
+	}
+	req := f.sent("SetSceneItemRender")[0]
+	if req["source"] != "Camera" || req["item"] != float64(3) {
+		t.Errorf("request: %v", req)
+	}
+}
@@ -0,0 +1,171 @@
+package go_obs
+
+import (
+	"errors"
+	"math"
+)
+
+// Rect is an area of the canvas, in pixels.
+type Rect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// FitMode describes how a source is placed into a rect.
+type FitMode int
+
+const (
+	// Scale the source to fit inside the rect, preserving its aspect ratio,
+	// and center it.
+	FitContain FitMode = iota
+	// Scale the source to cover the rect, preserving its aspect ratio, and
+	// crop whatever does not fit.
+	FitCover
+	// Scale the source to the size of the rect, ignoring its aspect ratio.
+	FitStretch
+)
+
+// Function FitRect returns the transform which places a source with the
+// given base size into rect. The transform resets rotation, crop and bounds,
+// so it does not depend on the item's current transform.
+func FitRect(sourceWidth, sourceHeight int, rect Rect, mode FitMode) ItemTransform {
+	sw, sh := float64(sourceWidth), float64(sourceHeight)
+	x, y := rect.X, rect.Y
+	scaleX, scaleY := rect.Width/sw, rect.Height/sh
+	var top, bottom, left, right int
+
+	switch mode {
+	case FitContain:
+		scale := math.Min(scaleX, scaleY)
+		scaleX, scaleY = scale, scale
+		x += (rect.Width - sw*scale) / 2
+		y += (rect.Height - sh*scale) / 2
+	case FitCover:
+		scale := math.Max(scaleX, scaleY)
+		scaleX, scaleY = scale, scale
+		// Crop happens before scaling, so it is in source pixels.
+		cropX := int(math.Round(sw - rect.Width/scale))
+		cropY := int(math.Round(sh - rect.Height/scale))
+		left, right = cropX/2, cropX-cropX/2
+		top, bottom = cropY/2, cropY-cropY/2
+	}
+
+	return ItemTransform{
+		Position: SetSceneItemPropertiesPosition{
+			X:         ptr(x),
+			Y:         ptr(y),
+			Alignment: ptr(AlignTopLeft),
+		},
+		Rotation: ptr(0.0),
+		Scale: SetSceneItemPropertiesScale{
+			X: ptr(scaleX),
+			Y: ptr(scaleY),
+		},
+		Crop: SetSceneItemPropertiesCrop{
+			Top:    ptr(top),
+			Bottom: ptr(bottom),
+			Left:   ptr(left),
+			Right:  ptr(right),
+		},
+		Bounds: SetSceneItemPropertiesBounds{
+			Type: "OBS_BOUNDS_NONE",
+		},
+	}
+}
+
+// Function GridRects divides the canvas into a grid of n cells, left to
+// right and top to bottom, separated by gap pixels. If cols is zero, the
+// grid is made as square as possible.
+func GridRects(canvas Rect, n int, cols int, gap float64) []Rect {
+	if n <= 0 {
+		return nil
+	}
+	if cols <= 0 {
+		cols = int(math.Ceil(math.Sqrt(float64(n))))
+	}
+	if cols > n {
+		cols = n
+	}
+	rows := (n + cols - 1) / cols
+	w := (canvas.Width - gap*float64(cols-1)) / float64(cols)
+	h := (canvas.Height - gap*float64(rows-1)) / float64(rows)
+
+	out := make([]Rect, n)
+	for i := range out {
+		col, row := i%cols, i/cols
+		out[i] = Rect{
+			X:      canvas.X + float64(col)*(w+gap),
+			Y:      canvas.Y + float64(row)*(h+gap),
+			Width:  w,
+			Height: h,
+		}
+	}
+	return out
+}
+
+// Function SplitRects divides the canvas into n equal parts separated by gap
+// pixels, side by side or, if vertical is true, stacked top to bottom.
+func SplitRects(canvas Rect, n int, vertical bool, gap float64) []Rect {
+	if vertical {
+		return GridRects(canvas, n, 1, gap)
+	}
+	return GridRects(canvas, n, n, gap)
+}
+
+// Function PipRects returns two rects for a picture-in-picture layout: the
+// whole canvas for the main item, and an inset of the given fraction of the
+// canvas size placed in a corner, margin pixels from the edges.
+func PipRects(canvas Rect, fraction float64, corner Alignment, margin float64) (main Rect, inset Rect) {
+	inset.Width = canvas.Width * fraction
+	inset.Height = canvas.Height * fraction
+	ax, ay := corner.Anchor()
+	inset.X = canvas.X + margin + (canvas.Width-inset.Width-2*margin)*ax
+	inset.Y = canvas.Y + margin + (canvas.Height-inset.Height-2*margin)*ay
+	return canvas, inset
+}
+
+// Function Canvas returns the base canvas of OBS as a rect.
+func (c *Client) Canvas() (Rect, error) {
+	res, err := c.GetVideoInfo()
+	if err != nil {
+		return Rect{}, err
+	}
+	return Rect{Width: float64(res.BaseWidth), Height: float64(res.BaseHeight)}, nil
+}
+
+// Function ArrangeItems places each item into the rect with the same index,
+// using the base size of its source, and applies the transforms in a single
+// ExecuteBatch request. The stacking order of the items is left unchanged.
+func (c *Client) ArrangeItems(sceneName string, items []ItemRef, rects []Rect, mode FitMode) error {
+	if len(items) != len(rects) {
+		return errors.New("number of items and rects differ")
+	}
+
+	reqs := make([]BatchRequest, len(items))
+	for i, item := range items {
+		props, err := c.GetSceneItemProperties(sceneName, item)
+		if err != nil {
+			return err
+		}
+		if props.SourceWidth <= 0 || props.SourceHeight <= 0 {
+			return errors.New("scene item has no size: " + item.String())
+		}
+		t := FitRect(props.SourceWidth, props.SourceHeight, rects[i], mode)
+		reqs[i] = BatchRequest{
+			RequestType: "SetSceneItemProperties",
+			Params: SetSceneItemPropertiesRequest{
+				SceneName: sceneName,
+				Item:      ItemByNameAndId(props.Name, props.ItemId),
+				Position:  t.Position,
+				Rotation:  t.Rotation,
+				Scale:     t.Scale,
+				Crop:      t.Crop,
+				Bounds:    t.Bounds,
+			},
+		}
+	}
+	_, err := c.Batch(reqs, false)
+	return err
+}
@@ -0,0 +1,61 @@
+package go_obs_test
+
+import (
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestFitRect(t *testing.T) {
+	rect := obs.Rect{X: 100, Y: 100, Width: 400, Height: 400}
+
+	// A 16:9 source fits inside a square with bars above and below.
+	fit := obs.FitRect(1920, 1080, rect, obs.FitContain)
+	if *fit.Scale.X != 400.0/1920 || *fit.Scale.Y != 400.0/1920 {
+		t.Errorf("contain scale: %v, %v", *fit.Scale.X, *fit.Scale.Y)
+	}
+	if *fit.Position.X != 100 || *fit.Position.Y != 100+(400-225)/2.0 {
+		t.Errorf("contain position: %v, %v", *fit.Position.X, *fit.Position.Y)
+	}
+
+	// Covering a square crops the sides of the source.
+	fill := obs.FitRect(1920, 1080, rect, obs.FitCover)
+	if *fill.Scale.X != 400.0/1080 || *fill.Position.X != 100 || *fill.Position.Y != 100 {
+		t.Errorf("cover: scale %v at %v, %v", *fill.Scale.X, *fill.Position.X, *fill.Position.Y)
+	}
+	if *fill.Crop.Left != 420 || *fill.Crop.Right != 420 || *fill.Crop.Top != 0 || *fill.Crop.Bottom != 0 {
+		t.Errorf("cover crop: %+v", fill.Crop)
+	}
+
+	stretch := obs.FitRect(1920, 1080, rect, obs.FitStretch)
+	if *stretch.Scale.X != 400.0/1920 || *stretch.Scale.Y != 400.0/1080 {
+		t.Errorf("stretch scale: %v, %v", *stretch.Scale.X, *stretch.Scale.Y)
+	}
+}
+
+func TestGridRects(t *testing.T) {
+	canvas := obs.Rect{Width: 1920, Height: 1080}
+	rects := obs.GridRects(canvas, 3, 0, 20)
+	want := []obs.Rect{
+		{X: 0, Y: 0, Width: 950, Height: 530},
+		{X: 970, Y: 0, Width: 950, Height: 530},
+		{X: 0, Y: 550, Width: 950, Height: 530},
+	}
+	if len(rects) != len(want) {
+		t.Fatalf("got %d rects", len(rects))
+	}
+	for i := range want {
+		if rects[i] != want[i] {
+			t.Errorf("rect %d: got %+v, want %+v", i, rects[i], want[i])
+		}
+	}
+
+	if split := obs.SplitRects(canvas, 2, false, 0); split[1] != (obs.Rect{X: 960, Width: 960, Height: 1080}) {
+		t.Errorf("split: %+v", split)
+	}
+
+	_, inset := obs.PipRects(canvas, 0.25, obs.AlignBottomRight, 40)
+	if inset != (obs.Rect{X: 1400, Y: 770, Width: 480, Height: 270}) {
+		t.Errorf("pip inset: %+v", inset)
+	}
+}
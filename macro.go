@@ -0,0 +1,469 @@
+package go_obs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Macro is a sequence of requests, waits and conditions which can be defined
+// in a JSON file and run without writing Go:
+//
+//	{
+//	  "name": "BRB",
+//	  "vars": {"scene": "BRB"},
+//	  "steps": [
+//	    {"request-type": "SetCurrentScene", "params": {"scene-name": "${scene}"},
+//	     "wait-for": "TransitionEnd", "match": {"to-scene": "${scene}"}, "timeout": "5s"},
+//	    {"request-type": "SetMute", "params": {"source": "Mic", "mute": true}},
+//	    {"wait": "2s"},
+//	    {"request-type": "GetCurrentScene", "save": {"current": "name"}},
+//	    {"if": {"var": "current", "equals": "BRB"},
+//	     "request-type": "SetSceneItemRender", "params": {"source": "Lower Third", "render": true}}
+//	  ]
+//	}
+//
+// Strings of the form `${name}` are replaced with the value of a variable,
+// keeping its type; variables within longer strings are formatted as text.
+type Macro struct {
+	Name string `json:"name,omitempty"`
+	// Default values of variables.
+	Vars  map[string]any `json:"vars,omitempty"`
+	Steps []MacroStep    `json:"steps"`
+}
+
+// MacroStep is a step of a macro. A step sends a request, waits for an
+// event, sleeps, or any combination of those, in this order: the event
+// listener is registered, the request is sent and its results saved, the
+// event is awaited, then the step sleeps.
+type MacroStep struct {
+	// Only run the step if the condition holds.
+	If *MacroCondition `json:"if,omitempty"`
+	// Request to send (eg. `SetCurrentScene`), with its parameters.
+	RequestType string         `json:"request-type,omitempty"`
+	Params      map[string]any `json:"params,omitempty"`
+	// Variables to set from the response, mapping variable names to fields
+	// of the response. Nested fields are separated by dots (eg.
+	// `stats.fps`).
+	Save map[string]string `json:"save,omitempty"`
+	// Event to wait for (eg. `TransitionEnd`), with fields which must match.
+	WaitFor string         `json:"wait-for,omitempty"`
+	Match   map[string]any `json:"match,omitempty"`
+	// How long to wait for the event before failing. Zero waits forever.
+	Timeout MacroDuration `json:"timeout,omitempty"`
+	// How long to sleep.
+	Wait MacroDuration `json:"wait,omitempty"`
+}
+
+// MacroCondition compares a variable with a value.
+type MacroCondition struct {
+	Var       string `json:"var"`
+	Equals    any    `json:"equals,omitempty"`
+	NotEquals any    `json:"not-equals,omitempty"`
+}
+
+// MacroDuration is a duration which is written in JSON as a string (eg.
+// "1.5s") or a number of milliseconds.
+type MacroDuration time.Duration
+
+func (d MacroDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *MacroDuration) UnmarshalJSON(data []byte) error {
+	var ms float64
+	if err := json.Unmarshal(data, &ms); err == nil {
+		*d = MacroDuration(ms * float64(time.Millisecond))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = MacroDuration(v)
+	return nil
+}
+
+// Function ParseMacro parses a macro from JSON and validates it.
+func ParseMacro(data []byte) (*Macro, error) {
+	m := &Macro{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, m.Validate()
+}
+
+// Function DecodeMacro converts a generic value, such as the
+// map[string]interface{} produced by a YAML decoder, into a macro and
+// validates it.
+func DecodeMacro(v any) (*Macro, error) {
+	m := &Macro{}
+	if err := remarshal(v, m); err != nil {
+		return nil, err
+	}
+	return m, m.Validate()
+}
+
+var macroVar = regexp.MustCompile(`\$\{([A-Za-z0-9_.-]+)\}`)
+
+// Function Validate checks that every step of the macro does something, and
+// that its requests and events exist, its parameters match the request
+// definitions and its matched fields match the event definitions.
+// Parameters and fields containing variables are only checked for presence.
+func (m *Macro) Validate() error {
+	for i, s := range m.Steps {
+		if err := s.validate(); err != nil {
+			return stepError(i, s, err)
+		}
+	}
+	return nil
+}
+
+func (s *MacroStep) validate() error {
+	if s.RequestType == "" && s.WaitFor == "" && s.Wait == 0 {
+		return errors.New("step has no request, event or wait")
+	}
+	if s.Wait < 0 || s.Timeout < 0 {
+		return errors.New("negative duration")
+	}
+	if s.If != nil && s.If.Var == "" {
+		return errors.New("condition has no variable")
+	}
+	if s.WaitFor != "" {
+		t, ok := eventTypes[s.WaitFor]
+		if !ok {
+			return errors.New("unknown event: " + s.WaitFor)
+		}
+		if err := validateMatch(t, s.Match); err != nil {
+			return err
+		}
+	} else if len(s.Match) > 0 || s.Timeout != 0 {
+		return errors.New("match and timeout require wait-for")
+	}
+	if s.RequestType == "" {
+		if len(s.Params) > 0 || len(s.Save) > 0 {
+			return errors.New("params and save require request-type")
+		}
+		return nil
+	}
+
+	t, ok := requestTypes[s.RequestType]
+	if !ok {
+		return errors.New("unknown request: " + s.RequestType)
+	}
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		required := len(tag) < 2 || tag[1] != "omitempty"
+		switch f.Type.Kind() {
+		case reflect.Struct, reflect.Ptr, reflect.Map, reflect.Slice:
+			// The generated structs don't say whether objects and arrays
+			// are optional, and most of them are (eg. the stream settings
+			// of StartStreaming). Item references are always required.
+			required = required && f.Type == reflect.TypeOf(ItemRef{})
+		}
+		fields[tag[0]] = required
+	}
+
+	literal := make(map[string]any)
+	for k, v := range s.Params {
+		if _, ok := fields[k]; !ok {
+			return errors.New("unknown parameter: " + k)
+		}
+		if !hasMacroVars(v) {
+			literal[k] = v
+		}
+	}
+	for k, required := range fields {
+		if _, ok := s.Params[k]; required && !ok {
+			return errors.New("missing parameter: " + k)
+		}
+	}
+	if err := remarshal(literal, reflect.New(t).Interface()); err != nil {
+		return errors.New("invalid parameters: " + err.Error())
+	}
+	return nil
+}
+
+// Function validateMatch checks that the matched fields exist in the event
+// type t, and that their values can be decoded into them.
+func validateMatch(t reflect.Type, match map[string]any) error {
+	fields := make(map[string]bool)
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous {
+				addFields(f.Type)
+				continue
+			}
+			fields[strings.Split(f.Tag.Get("json"), ",")[0]] = true
+		}
+	}
+	addFields(t)
+
+	literal := make(map[string]any)
+	for k, v := range match {
+		if !fields[k] {
+			return errors.New("unknown event field: " + k)
+		}
+		if !hasMacroVars(v) {
+			literal[k] = v
+		}
+	}
+	if err := remarshal(literal, reflect.New(t).Interface()); err != nil {
+		return errors.New("invalid match: " + err.Error())
+	}
+	return nil
+}
+
+func hasMacroVars(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return macroVar.MatchString(v)
+	case map[string]any:
+		for _, e := range v {
+			if hasMacroVars(e) {
+				return true
+			}
+		}
+	case []any:
+		for _, e := range v {
+			if hasMacroVars(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stepError(i int, s MacroStep, err error) error {
+	name := s.RequestType
+	if name == "" {
+		name = s.WaitFor
+	}
+	if name == "" {
+		name = "wait"
+	}
+	return errors.New("step " + strconv.Itoa(i+1) + " (" + name + "): " + err.Error())
+}
+
+// Function RunMacro validates and runs a macro. The given variables override
+// the defaults of the macro. It stops at the first failing step, or when ctx
+// is done.
+func (c *Client) RunMacro(ctx context.Context, m *Macro, vars map[string]any) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	env := make(map[string]any)
+	for k, v := range m.Vars {
+		env[k] = v
+	}
+	for k, v := range vars {
+		env[k] = v
+	}
+	for i, s := range m.Steps {
+		if err := c.runMacroStep(ctx, s, env); err != nil {
+			return stepError(i, s, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) runMacroStep(ctx context.Context, s MacroStep, env map[string]any) error {
+	if s.If != nil {
+		v := env[s.If.Var]
+		if s.If.Equals != nil && !jsonEqual(v, expandMacroVars(s.If.Equals, env)) {
+			return nil
+		}
+		if s.If.NotEquals != nil && jsonEqual(v, expandMacroVars(s.If.NotEquals, env)) {
+			return nil
+		}
+	}
+
+	var events chan struct{}
+	if s.WaitFor != "" {
+		match := expandMacroVars(s.Match, env).(map[string]any)
+		events = make(chan struct{}, 1)
+		remove := c.addListener(s.WaitFor, func(event any) {
+			var fields map[string]any
+			if remarshal(event, &fields) != nil {
+				return
+			}
+			for k, v := range match {
+				if !jsonEqual(fields[k], v) {
+					return
+				}
+			}
+			select {
+			case events <- struct{}{}:
+			default:
+			}
+		})
+		defer remove()
+	}
+
+	if s.RequestType != "" {
+		params := expandMacroVars(s.Params, env).(map[string]any)
+		res, err := c.request(ctx, s.RequestType, params)
+		if err != nil {
+			return err
+		}
+		for name, path := range s.Save {
+			v, ok := lookupPath(res, path)
+			if !ok {
+				return errors.New("no field in response: " + path)
+			}
+			env[name] = v
+		}
+	}
+
+	if events != nil {
+		var timeout <-chan time.Time
+		if s.Timeout > 0 {
+			timer := time.NewTimer(time.Duration(s.Timeout))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-events:
+		case <-timeout:
+			return errors.New("timed out waiting for event")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if s.Wait > 0 {
+		timer := time.NewTimer(time.Duration(s.Wait))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Function expandMacroVars replaces variables in all strings within v.
+func expandMacroVars(v any, env map[string]any) any {
+	switch v := v.(type) {
+	case string:
+		if m := macroVar.FindStringSubmatch(v); m != nil && m[0] == v {
+			if val, ok := env[m[1]]; ok {
+				return val
+			}
+		}
+		return macroVar.ReplaceAllStringFunc(v, func(s string) string {
+			if val, ok := env[s[2:len(s)-1]]; ok {
+				return fmt.Sprint(val)
+			}
+			return s
+		})
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = expandMacroVars(e, env)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = expandMacroVars(e, env)
+		}
+		return out
+	}
+	return v
+}
+
+// Function jsonEqual compares two values by their JSON representation, so
+// that eg. integers and floats with the same value are equal.
+func jsonEqual(a, b any) bool {
+	var na, nb any
+	if remarshal(a, &na) != nil || remarshal(b, &nb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+func lookupPath(v map[string]any, path string) (any, bool) {
+	var cur any = v
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// Function request sends a request with the given type and parameters, and
+// returns the response as a map. If ctx is done first, the response is
+// discarded when it arrives.
+func (c *Client) request(ctx context.Context, requestType string, params map[string]any) (map[string]any, error) {
+	uuid := uuid.NewString()
+	// Buffered, as an error may arrive after ctx is done.
+	errch := make(chan error, 1)
+	req := make(map[string]any, len(params)+2)
+	for k, v := range params {
+		req[k] = v
+	}
+	req["request-type"] = requestType
+	req["message-id"] = uuid
+
+	jdata, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	recvch := c.send(jdata, uuid, errch)
+	select {
+	case val := <-recvch:
+		res := make(map[string]any)
+		err = json.Unmarshal(val, &res)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	case err := <-errch:
+		return nil, err
+	case <-ctx.Done():
+		c.mx.Lock()
+		delete(c.errMap, uuid)
+		delete(c.recvMap, uuid)
+		delete(c.sent, uuid)
+		c.mx.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Function MacroRequestTypes returns the names of every request which can be
+// used in a macro, sorted.
+func MacroRequestTypes() []string {
+	out := make([]string, 0, len(requestTypes))
+	for k := range requestTypes {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,95 @@
+package go_obs_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestParseMacro(t *testing.T) {
+	m, err := obs.ParseMacro([]byte(`{
+		"name": "BRB",
+		"vars": {"scene": "BRB"},
+		"steps": [
+			{"request-type": "SetCurrentScene", "params": {"scene-name": "${scene}"},
+			 "wait-for": "TransitionEnd", "match": {"to-scene": "${scene}"}, "timeout": "5s"},
+			{"request-type": "SetMute", "params": {"source": "Mic", "mute": true}},
+			{"wait": 1500},
+			{"request-type": "GetCurrentScene", "save": {"current": "name"}},
+			{"if": {"var": "current", "equals": "BRB"},
+			 "request-type": "SetVolume", "params": {"source": "Music", "volume": "${volume}"}}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Steps) != 5 || m.Steps[2].Wait != obs.MacroDuration(1500*1e6) {
+		t.Errorf("unexpected macro: %+v", m)
+	}
+
+	cases := map[string]string{
+		`{"steps": [{}]}`: "no request, event or wait",
+		`{"steps": [{"request-type": "SetCurentScene"}]}`:                                    "unknown request",
+		`{"steps": [{"request-type": "SetMute", "params": {"source": "Mic"}}]}`:              "missing parameter: mute",
+		`{"steps": [{"request-type": "SetMute", "params": {"source": "Mic", "mute": 1}}]}`:   "invalid parameters",
+		`{"steps": [{"request-type": "SetMute", "params": {"sourc": "Mic", "mute": true}}]}`: "unknown parameter: sourc",
+		`{"steps": [{"wait-for": "TransitionEnded"}]}`:                                       "unknown event",
+		`{"steps": [{"wait": "1s", "match": {"a": 1}}]}`:                                     "require wait-for",
+		`{"steps": [{"wait-for": "TransitionEnd", "match": {"to_scene": "BRB"}}]}`:           "unknown event field: to_scene",
+		`{"steps": [{"wait-for": "TransitionEnd", "match": {"to-scene": 1}}]}`:               "invalid match",
+	}
+	for data, want := range cases {
+		_, err := obs.ParseMacro([]byte(data))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got %v, want %q", data, err, want)
+		}
+	}
+}
+
+func TestParseMacroOptionalObjects(t *testing.T) {
+	// Object parameters which OBS fills in with defaults may be left out.
+	_, err := obs.ParseMacro([]byte(`{"steps": [
+		{"request-type": "StartStreaming"},
+		{"request-type": "SetSceneItemProperties", "params": {"item": "Camera", "visible": false}},
+		{"request-type": "SetSceneItemProperties", "params": {"item": {"id": 3}, "position": {"x": 10}}}
+	]}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = obs.ParseMacro([]byte(`{"steps": [{"request-type": "SetSceneItemProperties", "params": {"visible": true}}]}`))
+	if err == nil || !strings.Contains(err.Error(), "missing parameter: item") {
+		t.Errorf("missing item: %v", err)
+	}
+}
+
+func TestRunMacroCancelRequest(t *testing.T) {
+	f := newFakeOBS(t)
+	release := make(chan struct{})
+	f.handle("SetCurrentScene", func(map[string]any) (map[string]any, error) {
+		<-release
+		return nil, nil
+	})
+	f.reply("GetCurrentScene", map[string]any{"name": "Main", "sources": []any{}})
+	c := f.connect()
+	m, err := obs.ParseMacro([]byte(`{"steps": [{"request-type": "SetCurrentScene", "params": {"scene-name": "BRB"}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.RunMacro(ctx, m, nil); err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("got %v", err)
+	}
+
+	// The late response is dropped without holding up other requests.
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	if res, err := c.GetCurrentScene(); err != nil || res.Name != "Main" {
+		t.Errorf("got %+v, %v", res, err)
+	}
+}
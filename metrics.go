@@ -0,0 +1,190 @@
+package go_obs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MetricsHandler is an http.Handler which exposes OBS health in the
+// Prometheus text exposition format. Stream metrics come from StreamStatus
+// events; OBS stats and output states are requested on each scrape, falling
+// back to the last Heartbeat event if the requests fail. A scrape waits at
+// most metricsTimeout for the requests; if OBS is slower than that, the
+// values of the last completed requests are reported.
+type MetricsHandler struct {
+	c             *Client
+	mx            sync.Mutex
+	status        *StreamStatusEvent
+	statusTime    time.Time
+	heartbeat     *HeartbeatEvent
+	heartbeatTime time.Time
+	fetching      chan struct{}
+	stats         *OBSStats
+	outputs       *GetStreamingStatusResponse
+	remove        []func()
+}
+
+// How long a scrape waits for OBS to answer requests.
+const metricsTimeout = 2 * time.Second
+
+// Function NewMetricsHandler creates a metrics handler for the given client.
+// The client does not need to be connected; while it is not, only the
+// connection and request metrics are reported.
+func NewMetricsHandler(c *Client) *MetricsHandler {
+	m := &MetricsHandler{c: c}
+	m.remove = []func(){
+		c.addListener("StreamStatus", func(event any) {
+			m.mx.Lock()
+			m.status, m.statusTime = event.(*StreamStatusEvent), time.Now()
+			m.mx.Unlock()
+		}),
+		c.addListener("Heartbeat", func(event any) {
+			m.mx.Lock()
+			m.heartbeat, m.heartbeatTime = event.(*HeartbeatEvent), time.Now()
+			m.mx.Unlock()
+		}),
+	}
+	return m
+}
+
+// Function Close stops the handler from following events.
+func (m *MetricsHandler) Close() {
+	for _, r := range m.remove {
+		r()
+	}
+}
+
+func (m *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), metricsTimeout)
+	defer cancel()
+	var buf bytes.Buffer
+	m.write(ctx, &buf)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// Function fetch requests the OBS stats and output states, unless an earlier
+// scrape's requests are still running, and waits for them until ctx is done.
+// It returns the results of the last completed requests, which are nil if
+// they failed.
+func (m *MetricsHandler) fetch(ctx context.Context) (*OBSStats, *GetStreamingStatusResponse) {
+	m.mx.Lock()
+	if m.fetching == nil {
+		done := make(chan struct{})
+		m.fetching = done
+		go func() {
+			var stats *OBSStats
+			if res, err := m.c.GetStats(); err == nil {
+				stats = &res.Stats
+			}
+			var outputs *GetStreamingStatusResponse
+			if res, err := m.c.GetStreamingStatus(); err == nil {
+				outputs = res
+			}
+			m.mx.Lock()
+			m.stats, m.outputs = stats, outputs
+			m.fetching = nil
+			m.mx.Unlock()
+			close(done)
+		}()
+	}
+	done := m.fetching
+	m.mx.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	return m.stats, m.outputs
+}
+
+func (m *MetricsHandler) write(ctx context.Context, buf *bytes.Buffer) {
+	gauge := func(name, help string, v float64) {
+		writeMetric(buf, name, "gauge", help, v)
+	}
+	counter := func(name, help string, v float64) {
+		writeMetric(buf, name, "counter", help, v)
+	}
+	bool01 := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	connected := m.c.Connected()
+	gauge("obs_up", "Whether the client is connected to OBS.", bool01(connected))
+
+	req := m.c.RequestStats()
+	buf.WriteString("# HELP obs_request_duration_seconds Time between sending requests and receiving their responses.\n")
+	buf.WriteString("# TYPE obs_request_duration_seconds summary\n")
+	buf.WriteString("obs_request_duration_seconds_sum " + formatMetric(req.TotalLatency.Seconds()) + "\n")
+	buf.WriteString("obs_request_duration_seconds_count " + strconv.Itoa(req.Count) + "\n")
+	counter("obs_request_errors_total", "Number of requests which received an error response.", float64(req.Errors))
+	gauge("obs_request_last_duration_seconds", "Latency of the most recent request.", req.LastLatency.Seconds())
+
+	m.mx.Lock()
+	status, statusTime := m.status, m.statusTime
+	heartbeat, heartbeatTime := m.heartbeat, m.heartbeatTime
+	m.mx.Unlock()
+
+	if heartbeat != nil {
+		gauge("obs_last_heartbeat_timestamp_seconds", "Time the last Heartbeat event was received.", float64(heartbeatTime.UnixNano())/1e9)
+	}
+	if !connected {
+		return
+	}
+
+	stats, outputs := m.fetch(ctx)
+	if stats == nil && heartbeat != nil {
+		stats = &heartbeat.Stats
+	}
+	if stats != nil {
+		gauge("obs_fps", "Current framerate.", stats.Fps)
+		gauge("obs_cpu_usage_percent", "Current CPU usage.", stats.CpuUsage)
+		gauge("obs_memory_usage_megabytes", "Current RAM usage.", stats.MemoryUsage)
+		gauge("obs_free_disk_space_megabytes", "Free recording disk space.", stats.FreeDiskSpace)
+		gauge("obs_average_frame_time_milliseconds", "Average frame render time.", stats.AverageFrameTime)
+		counter("obs_render_frames_total", "Number of frames rendered.", float64(stats.RenderTotalFrames))
+		counter("obs_render_missed_frames_total", "Number of frames missed due to rendering lag.", float64(stats.RenderMissedFrames))
+		counter("obs_output_frames_total", "Number of frames outputted.", float64(stats.OutputTotalFrames))
+		counter("obs_output_skipped_frames_total", "Number of frames skipped due to encoding lag.", float64(stats.OutputSkippedFrames))
+	}
+
+	streaming := false
+	if outputs != nil {
+		streaming = outputs.Streaming
+		gauge("obs_streaming", "Whether OBS is streaming.", bool01(outputs.Streaming))
+		gauge("obs_recording", "Whether OBS is recording.", bool01(outputs.Recording))
+		gauge("obs_recording_paused", "Whether recording is paused.", bool01(outputs.RecordingPaused))
+		gauge("obs_virtualcam", "Whether the virtual cam is active.", bool01(outputs.Virtualcam))
+	} else if heartbeat != nil && heartbeat.Streaming != nil && heartbeat.Recording != nil {
+		streaming = *heartbeat.Streaming
+		gauge("obs_streaming", "Whether OBS is streaming.", bool01(*heartbeat.Streaming))
+		gauge("obs_recording", "Whether OBS is recording.", bool01(*heartbeat.Recording))
+	}
+
+	if status != nil && streaming && time.Since(statusTime) < streamStatusTimeout {
+		gauge("obs_stream_kbits_per_second", "Amount of data per second transmitted by the stream encoder.", float64(status.KbitsPerSec))
+		gauge("obs_stream_strain_percent", "Percentage of dropped frames.", status.Strain)
+		gauge("obs_stream_duration_seconds", "Total time since the stream started.", float64(status.TotalStreamTime))
+		counter("obs_stream_frames_total", "Number of frames transmitted since the stream started.", float64(status.NumTotalFrames))
+		counter("obs_stream_dropped_frames_total", "Number of frames dropped by the encoder since the stream started.", float64(status.NumDroppedFrames))
+	}
+}
+
+func writeMetric(buf *bytes.Buffer, name, typ, help string, v float64) {
+	buf.WriteString("# HELP " + name + " " + help + "\n")
+	buf.WriteString("# TYPE " + name + " " + typ + "\n")
+	buf.WriteString(name + " " + formatMetric(v) + "\n")
+}
+
+func formatMetric(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,80 @@
+package go_obs_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestMetricsDisconnected(t *testing.T) {
+	m := obs.NewMetricsHandler(&obs.Client{})
+	defer m.Close()
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("content type: %s", ct)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE obs_up gauge\nobs_up 0\n",
+		"# TYPE obs_request_duration_seconds summary\n",
+		"obs_request_duration_seconds_count 0\n",
+		"obs_request_errors_total 0\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("missing %q in:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, "obs_fps") {
+		t.Errorf("unexpected stats while disconnected:\n%s", body)
+	}
+}
+
+func TestMetricsStalledOBS(t *testing.T) {
+	f := newFakeOBS(t)
+	release := make(chan struct{})
+	f.handle("GetStats", func(map[string]any) (map[string]any, error) {
+		<-release
+		return map[string]any{"stats": map[string]any{"fps": 60}}, nil
+	})
+	f.reply("GetStreamingStatus", map[string]any{"streaming": false, "recording": true})
+	m := obs.NewMetricsHandler(f.connect())
+	defer m.Close()
+
+	scrape := func() string {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		rec := httptest.NewRecorder()
+		start := time.Now()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil).WithContext(ctx))
+		if d := time.Since(start); d > time.Second {
+			t.Errorf("scrape took %v", d)
+		}
+		return rec.Body.String()
+	}
+
+	// While OBS does not answer, scrapes return without its values, and
+	// don't pile up requests.
+	for i := 0; i < 2; i++ {
+		body := scrape()
+		if !strings.Contains(body, "obs_up 1\n") || strings.Contains(body, "obs_fps") {
+			t.Errorf("stalled scrape:\n%s", body)
+		}
+	}
+	if n := len(f.sent("GetStats")); n != 1 {
+		t.Errorf("GetStats sent %d times", n)
+	}
+
+	close(release)
+	waitFor(t, "stats", func() bool {
+		return strings.Contains(scrape(), "obs_fps 60\n")
+	})
+	if body := scrape(); !strings.Contains(body, "obs_recording 1\n") {
+		t.Errorf("output states missing:\n%s", body)
+	}
+}
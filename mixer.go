@@ -0,0 +1,398 @@
+package go_obs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The lowest volume (in dB) which is not silent. OBS treats anything lower
+// as negative infinity.
+const MinVolumeDb = -100.0
+
+// Function mulToDb converts a volume multiplier to decibels.
+func mulToDb(mul float64) float64 {
+	if mul <= 0 {
+		return math.Inf(-1)
+	}
+	return 20 * math.Log10(mul)
+}
+
+// Function dbToMul converts a volume in decibels to a multiplier.
+func dbToMul(db float64) float64 {
+	if db < MinVolumeDb {
+		return 0
+	}
+	return math.Pow(10, db/20)
+}
+
+// Mixer keeps track of the audio settings of every source with audio, and
+// offers volume controls and fades which work in both decibels and
+// multipliers.
+type Mixer struct {
+	c          *Client
+	mx         sync.RWMutex
+	channels   map[string]*AudioChannel
+	audioKinds map[string]bool
+	fades      map[string]*fade
+	remove     []func()
+}
+
+// The audio settings of a source.
+type AudioChannel struct {
+	// Source name.
+	Name string
+	// Source kind (eg. `wasapi_input_capture`).
+	Kind string
+	// Volume as a multiplier.
+	Volume float64
+	// Volume in decibels.
+	VolumeDb float64
+	// Whether the source is muted.
+	Muted bool
+	// Monitor type. Options: `none`, `monitorOnly`, `monitorAndOutput`.
+	MonitorType string
+	// Audio sync offset.
+	SyncOffset time.Duration
+	// Whether each of the audio tracks 1-6 is active.
+	Tracks [6]bool
+}
+
+// FadeCurve describes how the volume changes over the course of a fade.
+type FadeCurve int
+
+const (
+	// The volume changes linearly in decibels, which sounds even.
+	FadeLinear FadeCurve = iota
+	// The volume multiplier changes linearly. When fading out, this is slow
+	// at first and drops off quickly towards the end.
+	FadeAmplitude
+	// The volume changes in decibels, slowly at first and at the end.
+	FadeSCurve
+)
+
+const fadeInterval = 50 * time.Millisecond
+
+var mixerEvents = []string{
+	"SourceCreated",
+	"SourceDestroyed",
+	"SourceRenamed",
+	"SourceVolumeChanged",
+	"SourceMuteStateChanged",
+	"SourceAudioSyncOffsetChanged",
+	"SourceAudioMixersChanged",
+}
+
+// Function NewMixer creates a mixer for the given client, which must be
+// connected, and loads the audio settings of every source with audio.
+func NewMixer(c *Client) (*Mixer, error) {
+	m := &Mixer{
+		c:        c,
+		channels: make(map[string]*AudioChannel),
+		fades:    make(map[string]*fade),
+	}
+
+	for _, e := range mixerEvents {
+		m.remove = append(m.remove, c.addListener(e, m.handle))
+	}
+	sources, audioKinds, err := c.getSourcesWithAudio()
+	if err != nil {
+		m.Close()
+		return nil, err
+	}
+	m.mx.Lock()
+	m.audioKinds = audioKinds
+	m.mx.Unlock()
+	for _, v := range sources.Sources {
+		if !m.audioKinds[v.TypeId] {
+			continue
+		}
+		ch, err := c.loadAudioChannel(v.Name, v.TypeId)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.mx.Lock()
+		m.channels[v.Name] = ch
+		m.mx.Unlock()
+	}
+	return m, nil
+}
+
+// Function getSourcesWithAudio lists every source, and returns whether each
+// source kind has audio.
+func (c *Client) getSourcesWithAudio() (*GetSourcesListResponse, map[string]bool, error) {
+	types, err := c.GetSourceTypesList()
+	if err != nil {
+		return nil, nil, err
+	}
+	audioKinds := make(map[string]bool)
+	for _, t := range types.Types {
+		audioKinds[t.TypeId] = t.Caps.HasAudio
+	}
+	sources, err := c.GetSourcesList()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sources, audioKinds, nil
+}
+
+// Function loadAudioChannel loads the complete audio settings of a source.
+func (c *Client) loadAudioChannel(name, kind string) (*AudioChannel, error) {
+	ch := &AudioChannel{Name: name, Kind: kind}
+	volume, err := c.GetVolume(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	ch.Volume, ch.VolumeDb, ch.Muted = volume.Volume, mulToDb(volume.Volume), volume.Muted
+	monitor, err := c.GetAudioMonitorType(name)
+	if err != nil {
+		return nil, err
+	}
+	ch.MonitorType = monitor.MonitorType
+	offset, err := c.GetSyncOffset(name)
+	if err != nil {
+		return nil, err
+	}
+	ch.SyncOffset = time.Duration(offset.Offset)
+	tracks, err := c.GetAudioTracks(name)
+	if err != nil {
+		return nil, err
+	}
+	ch.Tracks = [6]bool{tracks.Track1, tracks.Track2, tracks.Track3, tracks.Track4, tracks.Track5, tracks.Track6}
+	return ch, nil
+}
+
+// Function Close stops the mixer from following events and cancels any
+// running fades.
+func (m *Mixer) Close() {
+	m.mx.Lock()
+	remove := m.remove
+	m.remove = nil
+	for _, f := range m.fades {
+		f.cancel()
+	}
+	m.mx.Unlock()
+	for _, r := range remove {
+		r()
+	}
+}
+
+// Function Channels returns the audio settings of every source with audio,
+// sorted by name.
+func (m *Mixer) Channels() []AudioChannel {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	out := make([]AudioChannel, 0, len(m.channels))
+	for _, ch := range m.channels {
+		out = append(out, *ch)
+	}
+	sort.Slice(out, func(a, b int) bool {
+		return out[a].Name < out[b].Name
+	})
+	return out
+}
+
+// Function Channel returns the audio settings of the given source.
+func (m *Mixer) Channel(name string) (AudioChannel, bool) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	if ch, ok := m.channels[name]; ok {
+		return *ch, true
+	}
+	return AudioChannel{}, false
+}
+
+// Function SetVolume sets the volume of a source as a multiplier, and
+// cancels any fade running on it.
+func (m *Mixer) SetVolume(name string, mul float64) error {
+	m.cancelFade(name)
+	_, err := m.c.SetVolume(name, mul, nil)
+	return err
+}
+
+// Function SetVolumeDb sets the volume of a source in decibels, and cancels
+// any fade running on it.
+func (m *Mixer) SetVolumeDb(name string, db float64) error {
+	return m.SetVolume(name, dbToMul(db))
+}
+
+// Function SetMute mutes or unmutes a source.
+func (m *Mixer) SetMute(name string, muted bool) error {
+	_, err := m.c.SetMute(name, muted)
+	return err
+}
+
+// Function SetMonitorType sets the monitor type of a source.
+func (m *Mixer) SetMonitorType(name string, monitorType string) error {
+	if _, err := m.c.SetAudioMonitorType(name, monitorType); err != nil {
+		return err
+	}
+	// Monitor type changes have no event.
+	m.mx.Lock()
+	if ch, ok := m.channels[name]; ok {
+		ch.MonitorType = monitorType
+	}
+	m.mx.Unlock()
+	return nil
+}
+
+// Function Fade changes the volume of a source to the target (in dB) over
+// the given duration, following the given curve. It blocks until the fade
+// completes, ctx is canceled, or another fade or volume change on the same
+// source replaces it.
+func (m *Mixer) Fade(ctx context.Context, name string, targetDb float64, duration time.Duration, curve FadeCurve) error {
+	if _, ok := m.Channel(name); !ok {
+		return errors.New("no audio source: " + name)
+	}
+
+	// Replace any running fade, and wait for it to stop so that it cannot
+	// set the volume after this one starts.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	f := &fade{cancel, make(chan struct{})}
+	m.mx.Lock()
+	prev := m.fades[name]
+	m.fades[name] = f
+	m.mx.Unlock()
+	defer func() {
+		m.mx.Lock()
+		if m.fades[name] == f {
+			delete(m.fades, name)
+		}
+		m.mx.Unlock()
+		close(f.done)
+	}()
+	if prev != nil {
+		prev.cancel()
+		<-prev.done
+	}
+	ch, ok := m.Channel(name)
+	if !ok {
+		return errors.New("no audio source: " + name)
+	}
+
+	from := math.Max(ch.VolumeDb, MinVolumeDb)
+	to := math.Max(targetDb, MinVolumeDb)
+	fromMul, toMul := ch.Volume, dbToMul(targetDb)
+
+	ticker := time.NewTicker(fadeInterval)
+	defer ticker.Stop()
+	start := time.Now()
+	for {
+		t := 1.0
+		if duration > 0 {
+			t = math.Min(float64(time.Since(start))/float64(duration), 1)
+		}
+
+		var mul float64
+		switch curve {
+		case FadeAmplitude:
+			mul = fromMul + (toMul-fromMul)*t
+		case FadeSCurve:
+			mul = dbToMul(from + (to-from)*t*t*(3-2*t))
+		default:
+			mul = dbToMul(from + (to-from)*t)
+		}
+		if t == 1 {
+			mul = toMul
+		}
+		if _, err := m.c.SetVolume(name, mul, nil); err != nil {
+			return err
+		}
+		if t == 1 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// fade is a running volume fade.
+type fade struct {
+	cancel context.CancelFunc
+	// Closed once the fade has stopped setting the volume.
+	done chan struct{}
+}
+
+// Function cancelFade stops the fade running on a source, if any, and waits
+// for it to stop.
+func (m *Mixer) cancelFade(name string) {
+	m.mx.Lock()
+	f, ok := m.fades[name]
+	delete(m.fades, name)
+	m.mx.Unlock()
+	if ok {
+		f.cancel()
+		<-f.done
+	}
+}
+
+func (m *Mixer) handle(event any) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	switch e := event.(type) {
+	case *SourceCreatedEvent:
+		if m.audioKinds[e.SourceKind] {
+			if _, ok := m.channels[e.SourceName]; !ok {
+				m.channels[e.SourceName] = &AudioChannel{
+					Name:        e.SourceName,
+					Kind:        e.SourceKind,
+					Volume:      1,
+					MonitorType: "none",
+					Tracks:      [6]bool{true, true, true, true, true, true},
+				}
+				go m.refresh(e.SourceName, e.SourceKind)
+			}
+		}
+	case *SourceDestroyedEvent:
+		delete(m.channels, e.SourceName)
+	case *SourceRenamedEvent:
+		if ch, ok := m.channels[e.PreviousName]; ok {
+			delete(m.channels, e.PreviousName)
+			ch.Name = e.NewName
+			m.channels[e.NewName] = ch
+		}
+	case *SourceVolumeChangedEvent:
+		if ch, ok := m.channels[e.SourceName]; ok {
+			ch.Volume = float64(e.Volume)
+			ch.VolumeDb = float64(e.VolumeDb)
+		}
+	case *SourceMuteStateChangedEvent:
+		if ch, ok := m.channels[e.SourceName]; ok {
+			ch.Muted = e.Muted
+		}
+	case *SourceAudioSyncOffsetChangedEvent:
+		if ch, ok := m.channels[e.SourceName]; ok {
+			ch.SyncOffset = time.Duration(e.SyncOffset)
+		}
+	case *SourceAudioMixersChangedEvent:
+		if ch, ok := m.channels[e.SourceName]; ok {
+			for _, v := range e.Mixers {
+				if v.Id >= 1 && v.Id <= len(ch.Tracks) {
+					ch.Tracks[v.Id-1] = v.Enabled
+				}
+			}
+		}
+	}
+}
+
+// Function refresh loads the complete audio settings of a new source.
+func (m *Mixer) refresh(name, kind string) {
+	ch, err := m.c.loadAudioChannel(name, kind)
+	if err != nil {
+		return
+	}
+	m.mx.Lock()
+	if _, ok := m.channels[name]; ok {
+		m.channels[name] = ch
+	}
+	m.mx.Unlock()
+}
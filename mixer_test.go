@@ -0,0 +1,60 @@
+package go_obs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestMixerFadeReplaces(t *testing.T) {
+	f := newFakeOBS(t)
+	f.reply("GetSourceTypesList", map[string]any{"types": []any{
+		map[string]any{"typeId": "pulse_input_capture", "caps": map[string]any{"hasAudio": true}},
+	}})
+	f.reply("GetSourcesList", map[string]any{"sources": []any{
+		map[string]any{"name": "Mic", "typeId": "pulse_input_capture", "type": "input"},
+	}})
+	f.reply("GetVolume", map[string]any{"name": "Mic", "volume": 0.5})
+	f.reply("GetAudioMonitorType", map[string]any{"monitorType": "none"})
+	f.reply("GetSyncOffset", map[string]any{"name": "Mic", "offset": 0})
+	f.reply("GetAudioTracks", map[string]any{"track1": true})
+	var mx sync.Mutex
+	var volumes []float64
+	f.handle("SetVolume", func(req map[string]any) (map[string]any, error) {
+		mx.Lock()
+		volumes = append(volumes, req["volume"].(float64))
+		mx.Unlock()
+		return nil, nil
+	})
+	c := f.connect()
+
+	m, err := obs.NewMixer(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	first := make(chan error)
+	go func() {
+		first <- m.Fade(context.Background(), "Mic", obs.MinVolumeDb, time.Second, obs.FadeLinear)
+	}()
+	time.Sleep(120 * time.Millisecond)
+	if err := m.Fade(context.Background(), "Mic", 0, 0, obs.FadeLinear); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("first fade: %v", err)
+	}
+
+	// The replaced fade must not set the volume after the new one.
+	time.Sleep(150 * time.Millisecond)
+	mx.Lock()
+	defer mx.Unlock()
+	if len(volumes) < 2 || volumes[len(volumes)-1] != 1 {
+		t.Errorf("volumes: %v", volumes)
+	}
+}
@@ -0,0 +1,357 @@
+package go_obs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// OutputType identifies one of the outputs controlled by obs-websocket.
+type OutputType int
+
+const (
+	OutputStreaming OutputType = iota
+	OutputRecording
+	OutputReplayBuffer
+	OutputVirtualCam
+)
+
+func (o OutputType) String() string {
+	switch o {
+	case OutputStreaming:
+		return "streaming"
+	case OutputRecording:
+		return "recording"
+	case OutputReplayBuffer:
+		return "replay buffer"
+	case OutputVirtualCam:
+		return "virtual cam"
+	}
+	return "OutputType(" + strconv.Itoa(int(o)) + ")"
+}
+
+// OutputState is the lifecycle state of an output.
+type OutputState int
+
+const (
+	OutputStopped OutputState = iota
+	OutputStarting
+	OutputStarted
+	OutputStopping
+	OutputPaused
+)
+
+func (s OutputState) String() string {
+	switch s {
+	case OutputStopped:
+		return "stopped"
+	case OutputStarting:
+		return "starting"
+	case OutputStarted:
+		return "started"
+	case OutputStopping:
+		return "stopping"
+	case OutputPaused:
+		return "paused"
+	}
+	return "OutputState(" + strconv.Itoa(int(s)) + ")"
+}
+
+// OutputTracker follows the lifecycle of the streaming, recording, replay
+// buffer and virtual cam outputs from events, and allows waiting for them
+// to reach a given state.
+type OutputTracker struct {
+	c        *Client
+	mx       sync.Mutex
+	states   [4]OutputState
+	waiters  map[*outputWaiter]struct{}
+	onChange map[int]func(OutputType, OutputState, OutputState)
+	nextId   int
+	remove   []func()
+}
+
+type outputWaiter struct {
+	output OutputType
+	target OutputState
+	ch     chan error
+}
+
+var outputEvents = map[string]struct {
+	output OutputType
+	state  OutputState
+}{
+	"StreamStarting":    {OutputStreaming, OutputStarting},
+	"StreamStarted":     {OutputStreaming, OutputStarted},
+	"StreamStopping":    {OutputStreaming, OutputStopping},
+	"StreamStopped":     {OutputStreaming, OutputStopped},
+	"RecordingStarting": {OutputRecording, OutputStarting},
+	"RecordingStarted":  {OutputRecording, OutputStarted},
+	"RecordingStopping": {OutputRecording, OutputStopping},
+	"RecordingStopped":  {OutputRecording, OutputStopped},
+	"RecordingPaused":   {OutputRecording, OutputPaused},
+	"RecordingResumed":  {OutputRecording, OutputStarted},
+	"ReplayStarting":    {OutputReplayBuffer, OutputStarting},
+	"ReplayStarted":     {OutputReplayBuffer, OutputStarted},
+	"ReplayStopping":    {OutputReplayBuffer, OutputStopping},
+	"ReplayStopped":     {OutputReplayBuffer, OutputStopped},
+	"VirtualCamStarted": {OutputVirtualCam, OutputStarted},
+	"VirtualCamStopped": {OutputVirtualCam, OutputStopped},
+}
+
+// Function NewOutputTracker creates an output tracker for the given client,
+// which must be connected, and loads the current state of each output.
+func NewOutputTracker(c *Client) (*OutputTracker, error) {
+	t := &OutputTracker{
+		c:        c,
+		waiters:  make(map[*outputWaiter]struct{}),
+		onChange: make(map[int]func(OutputType, OutputState, OutputState)),
+	}
+	for name, v := range outputEvents {
+		output, state := v.output, v.state
+		t.remove = append(t.remove, c.addListener(name, func(any) {
+			t.set(output, state)
+		}))
+	}
+	if err := t.Refresh(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Function Close stops the tracker from following events. Any pending
+// waits fail.
+func (t *OutputTracker) Close() {
+	t.mx.Lock()
+	remove := t.remove
+	t.remove = nil
+	for w := range t.waiters {
+		w.ch <- errors.New("output tracker closed")
+		delete(t.waiters, w)
+	}
+	t.mx.Unlock()
+	for _, r := range remove {
+		r()
+	}
+}
+
+// Function Refresh loads the current state of each output with
+// GetStreamingStatus and GetReplayBufferStatus.
+func (t *OutputTracker) Refresh() error {
+	status, err := t.c.GetStreamingStatus()
+	if err != nil {
+		return err
+	}
+	replay, err := t.c.GetReplayBufferStatus()
+	if err != nil {
+		return err
+	}
+
+	state := func(active bool) OutputState {
+		if active {
+			return OutputStarted
+		}
+		return OutputStopped
+	}
+	t.set(OutputStreaming, state(status.Streaming))
+	if status.RecordingPaused {
+		t.set(OutputRecording, OutputPaused)
+	} else {
+		t.set(OutputRecording, state(status.Recording))
+	}
+	t.set(OutputReplayBuffer, state(replay.IsReplayBufferActive))
+	t.set(OutputVirtualCam, state(status.Virtualcam))
+	return nil
+}
+
+// Function State returns the current state of an output.
+func (t *OutputTracker) State(output OutputType) OutputState {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	return t.states[output]
+}
+
+// Function OnChange registers fn to be called whenever an output changes
+// state. The callback runs on the client's read loop, so it must not block
+// on requests. The returned function unregisters the callback.
+func (t *OutputTracker) OnChange(fn func(output OutputType, from OutputState, to OutputState)) func() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	id := t.nextId
+	t.nextId++
+	t.onChange[id] = fn
+	return func() {
+		t.mx.Lock()
+		defer t.mx.Unlock()
+		delete(t.onChange, id)
+	}
+}
+
+// Function Wait blocks until the output reaches the target state. It fails
+// if the output stops before reaching the target, or ctx is done.
+func (t *OutputTracker) Wait(ctx context.Context, output OutputType, target OutputState) error {
+	t.mx.Lock()
+	if t.states[output] == target {
+		t.mx.Unlock()
+		return nil
+	}
+	w := t.addWaiter(output, target)
+	t.mx.Unlock()
+	return t.wait(ctx, w)
+}
+
+// Function do registers a waiter before sending a request, so that no
+// events are missed, then waits for the output to reach the target state.
+func (t *OutputTracker) do(ctx context.Context, output OutputType, target OutputState, request func() error) error {
+	t.mx.Lock()
+	w := t.addWaiter(output, target)
+	t.mx.Unlock()
+	if err := request(); err != nil {
+		t.removeWaiter(w)
+		return err
+	}
+	return t.wait(ctx, w)
+}
+
+func (t *OutputTracker) addWaiter(output OutputType, target OutputState) *outputWaiter {
+	w := &outputWaiter{output, target, make(chan error, 1)}
+	t.waiters[w] = struct{}{}
+	return w
+}
+
+func (t *OutputTracker) removeWaiter(w *outputWaiter) {
+	t.mx.Lock()
+	delete(t.waiters, w)
+	t.mx.Unlock()
+}
+
+func (t *OutputTracker) wait(ctx context.Context, w *outputWaiter) error {
+	select {
+	case err := <-w.ch:
+		return err
+	case <-ctx.Done():
+		t.removeWaiter(w)
+		return ctx.Err()
+	}
+}
+
+func (t *OutputTracker) set(output OutputType, state OutputState) {
+	t.mx.Lock()
+	from := t.states[output]
+	t.states[output] = state
+	for w := range t.waiters {
+		if w.output != output {
+			continue
+		}
+		if state == w.target {
+			w.ch <- nil
+			delete(t.waiters, w)
+		} else if state == OutputStopped {
+			w.ch <- errors.New(output.String() + " stopped before reaching state " + w.target.String())
+			delete(t.waiters, w)
+		}
+	}
+	fns := make([]func(OutputType, OutputState, OutputState), 0, len(t.onChange))
+	for _, fn := range t.onChange {
+		fns = append(fns, fn)
+	}
+	t.mx.Unlock()
+
+	if from == state {
+		return
+	}
+	for _, fn := range fns {
+		fn(output, from, state)
+	}
+}
+
+// Function StartStreamingAndWait starts streaming and waits until the
+// stream is live.
+func (t *OutputTracker) StartStreamingAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputStreaming, OutputStarted, func() error {
+		_, err := t.c.StartStreaming(StartStreamingStream{})
+		return err
+	})
+}
+
+// Function StopStreamingAndWait stops streaming and waits until the stream
+// has stopped.
+func (t *OutputTracker) StopStreamingAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputStreaming, OutputStopped, func() error {
+		_, err := t.c.StopStreaming()
+		return err
+	})
+}
+
+// Function StartRecordingAndWait starts recording and waits until the
+// recording has started.
+func (t *OutputTracker) StartRecordingAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputRecording, OutputStarted, func() error {
+		_, err := t.c.StartRecording()
+		return err
+	})
+}
+
+// Function StopRecordingAndWait stops recording and waits until the
+// recording has stopped.
+func (t *OutputTracker) StopRecordingAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputRecording, OutputStopped, func() error {
+		_, err := t.c.StopRecording()
+		return err
+	})
+}
+
+// Function PauseRecordingAndWait pauses recording and waits until the
+// recording is paused.
+func (t *OutputTracker) PauseRecordingAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputRecording, OutputPaused, func() error {
+		_, err := t.c.PauseRecording()
+		return err
+	})
+}
+
+// Function ResumeRecordingAndWait resumes recording and waits until the
+// recording has resumed.
+func (t *OutputTracker) ResumeRecordingAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputRecording, OutputStarted, func() error {
+		_, err := t.c.ResumeRecording()
+		return err
+	})
+}
+
+// Function StartReplayBufferAndWait starts the replay buffer and waits
+// until it has started.
+func (t *OutputTracker) StartReplayBufferAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputReplayBuffer, OutputStarted, func() error {
+		_, err := t.c.StartReplayBuffer()
+		return err
+	})
+}
+
+// Function StopReplayBufferAndWait stops the replay buffer and waits until
+// it has stopped.
+func (t *OutputTracker) StopReplayBufferAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputReplayBuffer, OutputStopped, func() error {
+		_, err := t.c.StopReplayBuffer()
+		return err
+	})
+}
+
+// Function StartVirtualCamAndWait starts the virtual cam and waits until it
+// has started.
+func (t *OutputTracker) StartVirtualCamAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputVirtualCam, OutputStarted, func() error {
+		_, err := t.c.StartVirtualCam()
+		return err
+	})
+}
+
+// Function StopVirtualCamAndWait stops the virtual cam and waits until it
+// has stopped.
+func (t *OutputTracker) StopVirtualCamAndWait(ctx context.Context) error {
+	return t.do(ctx, OutputVirtualCam, OutputStopped, func() error {
+		_, err := t.c.StopVirtualCam()
+		return err
+	})
+}
@@ -0,0 +1,229 @@
+package go_obs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function fakeOutputs answers the status requests of an output tracker
+// with every output stopped.
+func fakeOutputs(f *fakeOBS) {
+	f.reply("GetStreamingStatus", map[string]any{"streaming": false, "recording": false})
+	f.reply("GetReplayBufferStatus", map[string]any{"isReplayBufferActive": false})
+}
+
+// Function emits makes requests of the given type succeed, and sends the
+// given events shortly after replying.
+func emits(f *fakeOBS, requestType string, events ...string) {
+	f.handle(requestType, func(map[string]any) (map[string]any, error) {
+		go func() {
+			for _, e := range events {
+				time.Sleep(5 * time.Millisecond)
+				f.event(e, nil)
+			}
+		}()
+		return nil, nil
+	})
+}
+
+func newOutputTracker(t *testing.T, f *fakeOBS) *obs.OutputTracker {
+	tracker, err := obs.NewOutputTracker(f.connect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(tracker.Close)
+	return tracker
+}
+
+func TestOutputTrackerStartStop(t *testing.T) {
+	tests := []struct {
+		output      obs.OutputType
+		start, stop string
+		started     []string
+		stopped     []string
+		startWait   func(*obs.OutputTracker, context.Context) error
+		stopWait    func(*obs.OutputTracker, context.Context) error
+	}{
+		{
+			obs.OutputStreaming, "StartStreaming", "StopStreaming",
+			[]string{"StreamStarting", "StreamStarted"},
+			[]string{"StreamStopping", "StreamStopped"},
+			(*obs.OutputTracker).StartStreamingAndWait,
+			(*obs.OutputTracker).StopStreamingAndWait,
+		},
+		{
+			obs.OutputRecording, "StartRecording", "StopRecording",
+			[]string{"RecordingStarting", "RecordingStarted"},
+			[]string{"RecordingStopping", "RecordingStopped"},
+			(*obs.OutputTracker).StartRecordingAndWait,
+			(*obs.OutputTracker).StopRecordingAndWait,
+		},
+		{
+			obs.OutputReplayBuffer, "StartReplayBuffer", "StopReplayBuffer",
+			[]string{"ReplayStarting", "ReplayStarted"},
+			[]string{"ReplayStopping", "ReplayStopped"},
+			(*obs.OutputTracker).StartReplayBufferAndWait,
+			(*obs.OutputTracker).StopReplayBufferAndWait,
+		},
+		{
+			obs.OutputVirtualCam, "StartVirtualCam", "StopVirtualCam",
+			[]string{"VirtualCamStarted"},
+			[]string{"VirtualCamStopped"},
+			(*obs.OutputTracker).StartVirtualCamAndWait,
+			(*obs.OutputTracker).StopVirtualCamAndWait,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output.String(), func(t *testing.T) {
+			f := newFakeOBS(t)
+			fakeOutputs(f)
+			emits(f, tt.start, tt.started...)
+			emits(f, tt.stop, tt.stopped...)
+			tracker := newOutputTracker(t, f)
+			var mx sync.Mutex
+			var states []obs.OutputState
+			tracker.OnChange(func(output obs.OutputType, from, to obs.OutputState) {
+				mx.Lock()
+				defer mx.Unlock()
+				if output == tt.output {
+					states = append(states, to)
+				}
+			})
+
+			ctx := context.Background()
+			if err := tt.startWait(tracker, ctx); err != nil {
+				t.Fatal(err)
+			}
+			if s := tracker.State(tt.output); s != obs.OutputStarted {
+				t.Errorf("state after start: %s", s)
+			}
+			if err := tt.stopWait(tracker, ctx); err != nil {
+				t.Fatal(err)
+			}
+			if s := tracker.State(tt.output); s != obs.OutputStopped {
+				t.Errorf("state after stop: %s", s)
+			}
+
+			want := []obs.OutputState{obs.OutputStarting, obs.OutputStarted, obs.OutputStopping, obs.OutputStopped}
+			if tt.output == obs.OutputVirtualCam {
+				want = []obs.OutputState{obs.OutputStarted, obs.OutputStopped}
+			}
+			mx.Lock()
+			defer mx.Unlock()
+			if len(states) != len(want) {
+				t.Fatalf("states: %v", states)
+			}
+			for i := range want {
+				if states[i] != want[i] {
+					t.Fatalf("states: %v", states)
+				}
+			}
+		})
+	}
+}
+
+func TestOutputTrackerPauseResume(t *testing.T) {
+	f := newFakeOBS(t)
+	f.reply("GetStreamingStatus", map[string]any{"recording": true})
+	f.reply("GetReplayBufferStatus", map[string]any{"isReplayBufferActive": false})
+	emits(f, "PauseRecording", "RecordingPaused")
+	emits(f, "ResumeRecording", "RecordingResumed")
+	tracker := newOutputTracker(t, f)
+	if s := tracker.State(obs.OutputRecording); s != obs.OutputStarted {
+		t.Fatalf("initial state: %s", s)
+	}
+
+	ctx := context.Background()
+	if err := tracker.PauseRecordingAndWait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s := tracker.State(obs.OutputRecording); s != obs.OutputPaused {
+		t.Errorf("state after pause: %s", s)
+	}
+	if err := tracker.ResumeRecordingAndWait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if s := tracker.State(obs.OutputRecording); s != obs.OutputStarted {
+		t.Errorf("state after resume: %s", s)
+	}
+}
+
+func TestOutputTrackerStartFails(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeOutputs(f)
+	f.handle("StartStreaming", func(map[string]any) (map[string]any, error) {
+		return nil, errors.New("no stream key")
+	})
+	emits(f, "StartRecording", "RecordingStarting", "RecordingStopped")
+	tracker := newOutputTracker(t, f)
+	ctx := context.Background()
+
+	// The request itself fails.
+	if err := tracker.StartStreamingAndWait(ctx); err == nil || err.Error() != "no stream key" {
+		t.Errorf("streaming: %v", err)
+	}
+	// The output stops while starting.
+	if err := tracker.StartRecordingAndWait(ctx); err == nil {
+		t.Error("recording started")
+	}
+	if s := tracker.State(obs.OutputRecording); s != obs.OutputStopped {
+		t.Errorf("recording state: %s", s)
+	}
+}
+
+func TestOutputTrackerWaitCancel(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeOutputs(f)
+	emits(f, "StartStreaming", "StreamStarting")
+	tracker := newOutputTracker(t, f)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tracker.StartStreamingAndWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("start: %v", err)
+	}
+	if s := tracker.State(obs.OutputStreaming); s != obs.OutputStarting {
+		t.Errorf("state: %s", s)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := tracker.Wait(ctx, obs.OutputStreaming, obs.OutputStarted); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait: %v", err)
+	}
+	// Waiting for the current state returns at once.
+	if err := tracker.Wait(ctx, obs.OutputStreaming, obs.OutputStarting); err != nil {
+		t.Errorf("wait for current state: %v", err)
+	}
+}
+
+func TestOutputTrackerCloseReleasesWaiters(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeOutputs(f)
+	tracker := newOutputTracker(t, f)
+
+	done := make(chan error, 2)
+	for _, output := range []obs.OutputType{obs.OutputStreaming, obs.OutputRecording} {
+		output := output
+		go func() {
+			done <- tracker.Wait(context.Background(), output, obs.OutputStarted)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	tracker.Close()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Error("wait succeeded after close")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("wait not released by close")
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package go_obs
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Playlist drives a media source (`ffmpeg_source` or `vlc_source`) through a
+// list of files, advancing to the next file when the current one ends.
+type Playlist struct {
+	c       *Client
+	source  string
+	kind    string
+	mx      sync.Mutex
+	files   []string
+	order   []int
+	pos     int
+	loop    bool
+	shuffle bool
+	loading bool
+	onEnd   map[int]func()
+	onFile  map[int]func(index int, file string)
+	nextId  int
+	remove  func()
+}
+
+// PlaylistPosition describes the playback position of a playlist.
+type PlaylistPosition struct {
+	// Index of the current file in the list of files.
+	Index int
+	// Path of the current file.
+	File string
+	// Time since the start of the current file.
+	Time time.Duration
+	// Duration of the current file.
+	Duration time.Duration
+}
+
+// Function NewPlaylist creates a playlist which plays the given files on a
+// media source. Playback starts once Play is called.
+func NewPlaylist(c *Client, sourceName string, files []string) (*Playlist, error) {
+	if len(files) == 0 {
+		return nil, errors.New("empty playlist")
+	}
+	res, err := c.GetSourceSettings(sourceName, "")
+	if err != nil {
+		return nil, err
+	}
+	if res.SourceType != "ffmpeg_source" && res.SourceType != "vlc_source" {
+		return nil, errors.New("not a media source: " + sourceName)
+	}
+
+	p := &Playlist{
+		c:      c,
+		source: sourceName,
+		kind:   res.SourceType,
+		files:  append([]string(nil), files...),
+		onEnd:  make(map[int]func()),
+		onFile: make(map[int]func(int, string)),
+	}
+	p.reorder()
+	p.remove = c.addListener("MediaEnded", func(event any) {
+		e := event.(*MediaEndedEvent)
+		p.mx.Lock()
+		ignore := e.SourceName != p.source || p.loading
+		p.mx.Unlock()
+		if !ignore {
+			go p.advance()
+		}
+	})
+	return p, nil
+}
+
+// Function Close stops the playlist from advancing. The media source keeps
+// playing the current file.
+func (p *Playlist) Close() {
+	p.remove()
+}
+
+// Function SetLoop sets whether the playlist starts over after the last
+// file.
+func (p *Playlist) SetLoop(loop bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.loop = loop
+}
+
+// Function SetShuffle sets whether the files are played in random order. The
+// current file keeps playing, and the rest of the files are reordered.
+func (p *Playlist) SetShuffle(shuffle bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.shuffle = shuffle
+	p.reorder()
+}
+
+// Function reorder recomputes the play order, keeping the current file at
+// the current position.
+func (p *Playlist) reorder() {
+	current := -1
+	if p.order != nil {
+		current = p.order[p.pos]
+	}
+	p.order = make([]int, len(p.files))
+	for i := range p.order {
+		p.order[i] = i
+	}
+	if p.shuffle {
+		rand.Shuffle(len(p.order), func(a, b int) {
+			p.order[a], p.order[b] = p.order[b], p.order[a]
+		})
+	}
+	if current < 0 {
+		return
+	}
+	for i, v := range p.order {
+		if v == current {
+			p.order[i], p.order[p.pos] = p.order[p.pos], p.order[i]
+			break
+		}
+	}
+}
+
+// Function OnFile registers fn to be called whenever the playlist starts
+// playing a file. The returned function unregisters the callback.
+func (p *Playlist) OnFile(fn func(index int, file string)) func() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	id := p.nextId
+	p.nextId++
+	p.onFile[id] = fn
+	return func() {
+		p.mx.Lock()
+		defer p.mx.Unlock()
+		delete(p.onFile, id)
+	}
+}
+
+// Function OnEnd registers fn to be called when the last file ends and the
+// playlist does not loop. The returned function unregisters the callback.
+func (p *Playlist) OnEnd(fn func()) func() {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	id := p.nextId
+	p.nextId++
+	p.onEnd[id] = fn
+	return func() {
+		p.mx.Lock()
+		defer p.mx.Unlock()
+		delete(p.onEnd, id)
+	}
+}
+
+// Function Current returns the index and path of the current file.
+func (p *Playlist) Current() (int, string) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	i := p.order[p.pos]
+	return i, p.files[i]
+}
+
+// Function Play loads the current file into the media source and plays it.
+func (p *Playlist) Play() error {
+	return p.skip(0)
+}
+
+// Function Next skips to the next file. After the last file, it starts over
+// if the playlist loops, and fails otherwise.
+func (p *Playlist) Next() error {
+	return p.skip(1)
+}
+
+// Function Previous skips to the previous file. Before the first file, it
+// goes to the last one if the playlist loops, and fails otherwise.
+func (p *Playlist) Previous() error {
+	return p.skip(-1)
+}
+
+// Function Jump skips to the file with the given index in the list of files.
+func (p *Playlist) Jump(index int) error {
+	p.mx.Lock()
+	if index < 0 || index >= len(p.files) {
+		p.mx.Unlock()
+		return errors.New("playlist index out of range")
+	}
+	for i, v := range p.order {
+		if v == index {
+			p.pos = i
+			break
+		}
+	}
+	p.mx.Unlock()
+	return p.skip(0)
+}
+
+// Function Position returns the current file and the playback position
+// within it, using GetMediaTime and GetMediaDuration.
+func (p *Playlist) Position() (PlaylistPosition, error) {
+	index, file := p.Current()
+	pos := PlaylistPosition{Index: index, File: file}
+	t, err := p.c.GetMediaTime(p.source)
+	if err != nil {
+		return pos, err
+	}
+	d, err := p.c.GetMediaDuration(p.source)
+	if err != nil {
+		return pos, err
+	}
+	pos.Time = time.Duration(t.Timestamp) * time.Millisecond
+	pos.Duration = time.Duration(d.MediaDuration) * time.Millisecond
+	return pos, nil
+}
+
+// Function advance moves to the next file when the current one ends.
+func (p *Playlist) advance() {
+	if err := p.skip(1); err == nil {
+		return
+	}
+	p.mx.Lock()
+	fns := make([]func(), 0, len(p.onEnd))
+	for _, fn := range p.onEnd {
+		fns = append(fns, fn)
+	}
+	p.mx.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+func (p *Playlist) skip(n int) error {
+	p.mx.Lock()
+	pos := p.pos + n
+	if pos < 0 || pos >= len(p.order) {
+		if !p.loop {
+			p.mx.Unlock()
+			return errors.New("no more files in playlist")
+		}
+		pos = (pos + len(p.order)) % len(p.order)
+		if pos == 0 && p.shuffle {
+			p.pos = 0
+			p.order = nil
+			p.reorder()
+		}
+	}
+	p.pos = pos
+	index := p.order[pos]
+	file := p.files[index]
+	p.loading = true
+	p.mx.Unlock()
+
+	err := p.load(file)
+	p.mx.Lock()
+	p.loading = false
+	fns := make([]func(int, string), 0, len(p.onFile))
+	for _, fn := range p.onFile {
+		fns = append(fns, fn)
+	}
+	p.mx.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, fn := range fns {
+		fn(index, file)
+	}
+	return nil
+}
+
+// Function load points the media source at a file and starts playing it.
+func (p *Playlist) load(file string) error {
+	var settings SourceSettings
+	if p.kind == "vlc_source" {
+		settings = VLCSourceSettings{
+			Playlist: []VLCPlaylistItem{{Value: file}},
+			Loop:     ptr(false),
+		}
+	} else {
+		settings = FFmpegSourceSettings{
+			IsLocalFile: ptr(true),
+			LocalFile:   ptr(file),
+			Looping:     ptr(false),
+		}
+	}
+	if err := p.c.SetTypedSourceSettings(p.source, settings); err != nil {
+		return err
+	}
+	_, err := p.c.RestartMedia(p.source)
+	return err
+}
@@ -0,0 +1,136 @@
+package go_obs_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function newPlaylist returns a playlist of the given files on a fake media
+// source, and a function returning the files loaded into it so far.
+func newPlaylist(t *testing.T, files ...string) (*obs.Playlist, *fakeOBS, func() []string) {
+	f := newFakeOBS(t)
+	f.reply("GetSourceSettings", map[string]any{"sourceName": "Media", "sourceType": "ffmpeg_source", "sourceSettings": map[string]any{}})
+	f.reply("SetSourceSettings", map[string]any{})
+	f.reply("RestartMedia", map[string]any{})
+	c := f.connect()
+	p, err := obs.NewPlaylist(c, "Media", files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(p.Close)
+	return p, f, func() []string {
+		var out []string
+		for _, req := range f.sent("SetSourceSettings") {
+			out = append(out, req["sourceSettings"].(map[string]any)["local_file"].(string))
+		}
+		return out
+	}
+}
+
+func TestPlaylistOrder(t *testing.T) {
+	p, _, loaded := newPlaylist(t, "a", "b", "c")
+
+	steps := []struct {
+		name string
+		fn   func() error
+		ok   bool
+	}{
+		{"play", p.Play, true},
+		{"next", p.Next, true},
+		{"next", p.Next, true},
+		{"next past the end", p.Next, false},
+		{"previous", p.Previous, true},
+		{"jump", func() error { return p.Jump(0) }, true},
+		{"previous before the start", p.Previous, false},
+		{"jump out of range", func() error { return p.Jump(3) }, false},
+		{"loop", func() error { p.SetLoop(true); return p.Previous() }, true},
+		{"next after looping", p.Next, true},
+	}
+	for _, s := range steps {
+		if err := s.fn(); (err == nil) != s.ok {
+			t.Errorf("%s: %v", s.name, err)
+		}
+	}
+	want := []string{"a", "b", "c", "b", "a", "c", "a"}
+	if got := loaded(); !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded %v, want %v", got, want)
+	}
+	if i, file := p.Current(); i != 0 || file != "a" {
+		t.Errorf("current: %d %q", i, file)
+	}
+}
+
+func TestPlaylistShuffle(t *testing.T) {
+	files := []string{"a", "b", "c", "d", "e", "f"}
+	p, _, loaded := newPlaylist(t, files...)
+	if err := p.Jump(2); err != nil {
+		t.Fatal(err)
+	}
+	p.SetShuffle(true)
+	if i, _ := p.Current(); i != 2 {
+		t.Errorf("shuffle changed the current file to %d", i)
+	}
+
+	// Every file plays once per round, and the order is shuffled again when
+	// the playlist loops.
+	p, _, loaded = newPlaylist(t, files...)
+	p.SetShuffle(true)
+	p.SetLoop(true)
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2*len(files)-1; i++ {
+		if err := p.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := loaded()
+	for _, round := range [][]string{got[:len(files)], got[len(files):]} {
+		round = append([]string(nil), round...)
+		sort.Strings(round)
+		if !reflect.DeepEqual(round, files) {
+			t.Errorf("shuffled round played %v", round)
+		}
+	}
+}
+
+func TestPlaylistAdvance(t *testing.T) {
+	p, f, loaded := newPlaylist(t, "a", "b")
+	files := make(chan string, 4)
+	p.OnFile(func(index int, file string) {
+		files <- file
+	})
+	ended := make(chan struct{}, 1)
+	p.OnEnd(func() {
+		ended <- struct{}{}
+	})
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+	<-files
+
+	f.event("MediaEnded", map[string]any{"sourceName": "Other"})
+	f.event("MediaEnded", map[string]any{"sourceName": "Media"})
+	select {
+	case file := <-files:
+		if file != "b" {
+			t.Errorf("advanced to %q", file)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("did not advance")
+	}
+
+	f.event("MediaEnded", map[string]any{"sourceName": "Media"})
+	select {
+	case <-ended:
+	case <-time.After(time.Second):
+		t.Fatal("end not reported")
+	}
+	if got := loaded(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("loaded %v", got)
+	}
+}
@@ -0,0 +1,503 @@
+package go_obs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Layout describes the desired state of some scenes. Scenes, items and
+// filters which exist in OBS but are not described are left alone.
+type Layout struct {
+	Scenes []LayoutScene
+}
+
+// The desired state of a scene.
+type LayoutScene struct {
+	// Name of the scene.
+	Name string
+	// Items of the scene, from top to bottom.
+	Items []LayoutItem
+}
+
+// The desired state of a scene item and its source.
+type LayoutItem struct {
+	// Name of the item's source.
+	Source string
+	// Kind of the source, used if it must be created. May be omitted if
+	// Settings implements SourceSettings.
+	Kind string
+	// Settings of the source. Only the settings given are compared and
+	// updated.
+	Settings interface{}
+	// Transform of the item, if it should be managed.
+	Transform *ItemTransform
+	// Visibility of the item, if it should be managed.
+	Visible *bool
+	// Lock state of the item, if it should be managed.
+	Locked *bool
+	// Filters of the source.
+	Filters []LayoutFilter
+}
+
+// The desired state of a filter.
+type LayoutFilter struct {
+	// Name of the filter.
+	Name string
+	// Kind of the filter, used if it must be created. May be omitted if
+	// Settings implements FilterSettings.
+	Kind string
+	// Settings of the filter. Only the settings given are compared and
+	// updated.
+	Settings interface{}
+	// Whether the filter is enabled, if it should be managed.
+	Enabled *bool
+}
+
+// Plan is an ordered list of the requests needed to make OBS match a
+// Layout.
+type Plan struct {
+	Steps []PlanStep
+}
+
+// A single request of a Plan.
+type PlanStep struct {
+	// The request type, eg. `CreateScene`.
+	RequestType string
+	// Human readable description of the step.
+	Description string
+	run         func(c *Client) error
+}
+
+// Function String returns the plan with one step per line.
+func (p *Plan) String() string {
+	if len(p.Steps) == 0 {
+		return "no changes\n"
+	}
+	b := strings.Builder{}
+	for i, s := range p.Steps {
+		fmt.Fprintf(&b, "%d. %s: %s\n", i+1, s.RequestType, s.Description)
+	}
+	return b.String()
+}
+
+// Function Apply executes each step of the plan in order, stopping at the
+// first error.
+func (p *Plan) Apply(c *Client) error {
+	for i, s := range p.Steps {
+		if err := s.run(c); err != nil {
+			return fmt.Errorf("step %d (%s: %s): %w", i+1, s.RequestType, s.Description, err)
+		}
+	}
+	return nil
+}
+
+func (p *Plan) add(requestType string, run func(c *Client) error, format string, args ...any) {
+	p.Steps = append(p.Steps, PlanStep{
+		RequestType: requestType,
+		Description: fmt.Sprintf(format, args...),
+		run:         run,
+	})
+}
+
+// Function ReconcileLayout compares the layout with the current state of
+// OBS and applies the changes needed to make them match. If dryRun is set,
+// the plan is returned without being applied.
+func (c *Client) ReconcileLayout(l Layout, dryRun bool) (*Plan, error) {
+	plan, err := c.PlanLayout(l)
+	if err != nil || dryRun {
+		return plan, err
+	}
+	return plan, plan.Apply(c)
+}
+
+// Function PlanLayout compares the layout with the current state of OBS and
+// returns the changes needed to make them match, without applying them.
+func (c *Client) PlanLayout(l Layout) (*Plan, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+
+	sceneList, err := c.GetSceneList()
+	if err != nil {
+		return nil, err
+	}
+	scenes := make(map[string][]SceneItem)
+	for _, s := range sceneList.Scenes {
+		scenes[s.Name] = s.Sources
+	}
+	sourceList, err := c.GetSourcesList()
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]string)
+	for _, s := range sourceList.Sources {
+		sources[s.Name] = s.TypeId
+	}
+	// Scenes are not listed as sources, but can be nested in other scenes.
+	for name := range scenes {
+		sources[name] = "scene"
+	}
+
+	// Missing scenes are created first, so that they can be nested in
+	// other scenes.
+	plan := &Plan{}
+	created := make(map[string]bool)
+	for _, ls := range l.Scenes {
+		if _, ok := scenes[ls.Name]; !ok {
+			name := ls.Name
+			plan.add("CreateScene", func(c *Client) error {
+				_, err := c.CreateScene(name)
+				return err
+			}, "create scene %q", name)
+			created[name] = true
+		}
+	}
+
+	done := make(map[string]bool)
+	for _, ls := range l.Scenes {
+		items := scenes[ls.Name]
+
+		// Items are matched to existing items by their source, in order.
+		// The ID of each item is kept in the order of the layout; those of
+		// new items are filled in when they are created.
+		used := make(map[int]bool)
+		added := []string{}
+		ids := []*int{}
+		for _, li := range ls.Items {
+			var match *SceneItem
+			for i, v := range items {
+				if v.Name == li.Source && !used[v.Id] {
+					match = &items[i]
+					used[v.Id] = true
+					break
+				}
+			}
+
+			id := new(int)
+			kind, exists := sources[li.Source]
+			if !exists && !created[li.Source] {
+				plan.createSource(ls.Name, li, id)
+				created[li.Source] = true
+				added = append(added, li.Source)
+			} else if match == nil {
+				plan.addSceneItem(ls.Name, li, id)
+				added = append(added, li.Source)
+			}
+			ids = append(ids, id)
+
+			if match == nil {
+				// New items have the default transform, so any managed
+				// properties must be set.
+				if li.Transform != nil || li.Locked != nil {
+					plan.setItemProperties(ls.Name, id, li)
+				}
+			} else {
+				*id = match.Id
+				if err = c.planItemProperties(plan, ls.Name, match.Id, li); err != nil {
+					return nil, err
+				}
+			}
+
+			if !done[li.Source] {
+				if want := li.kind(); exists && want != "" && want != kind {
+					return nil, fmt.Errorf("source %q is of kind %q, not %q", li.Source, kind, want)
+				}
+				if exists {
+					if err = c.planSourceSettings(plan, li); err != nil {
+						return nil, err
+					}
+				}
+				if err = c.planFilters(plan, li, exists); err != nil {
+					return nil, err
+				}
+			}
+			done[li.Source] = true
+		}
+
+		// New items are added to the top of the scene, so the predicted
+		// order is compared against the desired order.
+		order := []string{}
+		for i := len(added) - 1; i >= 0; i-- {
+			order = append(order, added[i])
+		}
+		for _, v := range items {
+			if used[v.Id] {
+				order = append(order, v.Name)
+			}
+		}
+		want := []string{}
+		for _, li := range ls.Items {
+			want = append(want, li.Source)
+		}
+		if !reflect.DeepEqual(order, want) {
+			plan.reorder(ls.Name, want, ids)
+		}
+	}
+	return plan, nil
+}
+
+// Function createSource adds a step which creates a source with an item in
+// the given scene, and stores the ID of the new item in id.
+func (p *Plan) createSource(scene string, li LayoutItem, id *int) {
+	name, kind, settings, visible := li.Source, li.kind(), li.Settings, li.Visible
+	p.add("CreateSource", func(c *Client) error {
+		res, err := c.CreateSource(name, kind, scene, settings, visible)
+		if err != nil {
+			return err
+		}
+		*id = res.ItemId
+		return nil
+	}, "create %s source %q in scene %q", kind, name, scene)
+}
+
+// Function addSceneItem adds a step which adds an existing source to the
+// given scene, and stores the ID of the new item in id.
+func (p *Plan) addSceneItem(scene string, li LayoutItem, id *int) {
+	name, visible := li.Source, li.Visible
+	p.add("AddSceneItem", func(c *Client) error {
+		res, err := c.AddSceneItem(scene, name, visible)
+		if err != nil {
+			return err
+		}
+		*id = res.ItemId
+		return nil
+	}, "add source %q to scene %q", name, scene)
+}
+
+// Function setItemProperties adds a step which updates the item with the
+// given ID. The ID is read when the step runs, so that it may refer to an
+// item created by an earlier step.
+func (p *Plan) setItemProperties(scene string, id *int, li LayoutItem) {
+	t := ItemTransform{}
+	if li.Transform != nil {
+		t = *li.Transform
+	}
+	name, visible, locked := li.Source, li.Visible, li.Locked
+	p.add("SetSceneItemProperties", func(c *Client) error {
+		return c.SetItemTransform(scene, ItemByNameAndId(name, *id), t, visible, locked)
+	}, "update item %q in scene %q", name, scene)
+}
+
+// Function reorder adds a step which puts the items with the given IDs in
+// order. Items not described by the layout keep their positions, and the
+// described items are reordered among the positions they occupy.
+func (p *Plan) reorder(scene string, want []string, ids []*int) {
+	p.add("ReorderSceneItems", func(c *Client) error {
+		res, err := c.GetSceneList()
+		if err != nil {
+			return err
+		}
+		var items []SceneItem
+		for _, s := range res.Scenes {
+			if s.Name == scene {
+				items = s.Sources
+			}
+		}
+
+		// Item IDs are only known once any new items have been created.
+		managed := make(map[int]bool)
+		for _, id := range ids {
+			managed[*id] = true
+		}
+		names := make(map[int]string)
+		for _, v := range items {
+			names[v.Id] = v.Name
+		}
+		order := []ItemRef{}
+		next := 0
+		for _, v := range items {
+			if managed[v.Id] && next < len(ids) {
+				id := *ids[next]
+				order = append(order, ItemByNameAndId(names[id], id))
+				next++
+			} else {
+				order = append(order, ItemByNameAndId(v.Name, v.Id))
+			}
+		}
+
+		// Scene item lists are ordered from top to bottom, while
+		// ReorderSceneItems expects them from bottom to top.
+		reorder := make([]ReorderSceneItemsItems, len(order))
+		for i, v := range order {
+			reorder[len(order)-1-i] = v.ReorderItem()
+		}
+		_, err = c.ReorderSceneItems(scene, reorder)
+		return err
+	}, "reorder scene %q to %s", scene, strings.Join(want, ", "))
+}
+
+func (c *Client) planItemProperties(plan *Plan, scene string, id int, li LayoutItem) error {
+	if li.Transform == nil && li.Visible == nil && li.Locked == nil {
+		return nil
+	}
+	res, err := c.GetSceneItemProperties(scene, ItemById(id))
+	if err != nil {
+		return err
+	}
+	if (li.Transform == nil || li.Transform.Matches(res)) &&
+		matchValue(li.Visible, res.Visible) &&
+		matchValue(li.Locked, res.Locked) {
+		return nil
+	}
+	plan.setItemProperties(scene, &id, li)
+	return nil
+}
+
+func (c *Client) planSourceSettings(plan *Plan, li LayoutItem) error {
+	if li.Settings == nil {
+		return nil
+	}
+	res, err := c.GetSourceSettings(li.Source, "")
+	if err != nil {
+		return err
+	}
+	same, err := settingsMatch(li.Settings, res.SourceSettings)
+	if err != nil || same {
+		return err
+	}
+	name, settings := li.Source, li.Settings
+	plan.add("SetSourceSettings", func(c *Client) error {
+		_, err := c.SetSourceSettings(name, "", settings)
+		return err
+	}, "update settings of source %q", name)
+	return nil
+}
+
+func (c *Client) planFilters(plan *Plan, li LayoutItem, exists bool) error {
+	if len(li.Filters) == 0 {
+		return nil
+	}
+	type filter struct {
+		kind     string
+		enabled  bool
+		settings interface{}
+	}
+	existing := make(map[string]filter)
+	if exists {
+		res, err := c.GetSourceFilters(li.Source)
+		if err != nil {
+			return err
+		}
+		for _, f := range res.Filters {
+			existing[f.Name] = filter{f.Type, f.Enabled, f.Settings}
+		}
+	}
+
+	source := li.Source
+	for _, lf := range li.Filters {
+		name, kind, settings, enabled := lf.Name, lf.kind(), lf.Settings, lf.Enabled
+		f, ok := existing[name]
+		if !ok {
+			plan.add("AddFilterToSource", func(c *Client) error {
+				_, err := c.AddFilterToSource(source, name, kind, settings)
+				return err
+			}, "add %s filter %q to source %q", kind, name, source)
+			if enabled != nil && !*enabled {
+				plan.add("SetSourceFilterVisibility", func(c *Client) error {
+					_, err := c.SetSourceFilterVisibility(source, name, false)
+					return err
+				}, "disable filter %q of source %q", name, source)
+			}
+			continue
+		}
+
+		if kind != "" && kind != f.kind {
+			return fmt.Errorf("filter %q of source %q is of kind %q, not %q", name, source, f.kind, kind)
+		}
+		if settings != nil {
+			same, err := settingsMatch(settings, f.settings)
+			if err != nil {
+				return err
+			}
+			if !same {
+				plan.add("SetSourceFilterSettings", func(c *Client) error {
+					_, err := c.SetSourceFilterSettings(source, name, settings)
+					return err
+				}, "update settings of filter %q of source %q", name, source)
+			}
+		}
+		if enabled != nil && *enabled != f.enabled {
+			plan.add("SetSourceFilterVisibility", func(c *Client) error {
+				_, err := c.SetSourceFilterVisibility(source, name, *enabled)
+				return err
+			}, "set filter %q of source %q enabled to %t", name, source, *enabled)
+		}
+	}
+	return nil
+}
+
+func (l Layout) validate() error {
+	scenes := make(map[string]bool)
+	for _, s := range l.Scenes {
+		if s.Name == "" {
+			return errors.New("scene has no name")
+		}
+		if scenes[s.Name] {
+			return fmt.Errorf("duplicate scene %q", s.Name)
+		}
+		scenes[s.Name] = true
+		for _, item := range s.Items {
+			if item.Source == "" {
+				return fmt.Errorf("item in scene %q has no source", s.Name)
+			}
+			for _, f := range item.Filters {
+				if f.Name == "" {
+					return fmt.Errorf("filter of source %q has no name", item.Source)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (li LayoutItem) kind() string {
+	if li.Kind != "" {
+		return li.Kind
+	}
+	if s, ok := li.Settings.(SourceSettings); ok {
+		return s.SourceKind()
+	}
+	return ""
+}
+
+func (lf LayoutFilter) kind() string {
+	if lf.Kind != "" {
+		return lf.Kind
+	}
+	if s, ok := lf.Settings.(FilterSettings); ok {
+		return s.FilterKind()
+	}
+	return ""
+}
+
+// Function settingsMatch returns whether every setting in want has the
+// same value in have.
+func settingsMatch(want interface{}, have interface{}) (bool, error) {
+	var w, h interface{}
+	if err := remarshal(want, &w); err != nil {
+		return false, err
+	}
+	if err := remarshal(have, &h); err != nil {
+		return false, err
+	}
+	return isSubset(w, h), nil
+}
+
+func isSubset(want, have interface{}) bool {
+	wm, ok := want.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(want, have)
+	}
+	hm, ok := have.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, v := range wm {
+		if !isSubset(v, hm[k]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package go_obs_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestPlanLayoutNestedScene(t *testing.T) {
+	f := newFakeOBS(t)
+	f.reply("GetSceneList", map[string]any{
+		"current-scene": "Main",
+		"scenes": []any{
+			map[string]any{"name": "Main", "sources": []any{}},
+			map[string]any{"name": "Intro", "sources": []any{}},
+		},
+	})
+	f.reply("GetSourcesList", map[string]any{
+		"sources": []any{
+			map[string]any{"name": "Mic", "typeId": "pulse_input_capture", "type": "input"},
+		},
+	})
+	c := f.connect()
+
+	plan, err := c.PlanLayout(obs.Layout{Scenes: []obs.LayoutScene{
+		{Name: "Main", Items: []obs.LayoutItem{{Source: "Intro"}, {Source: "Outro"}}},
+		{Name: "Outro"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, s := range plan.Steps {
+		types = append(types, s.RequestType)
+	}
+	// Both the existing and the new scene are added as items, not created
+	// as sources.
+	want := []string{"CreateScene", "AddSceneItem", "AddSceneItem", "ReorderSceneItems"}
+	if len(types) != len(want) {
+		t.Fatalf("got plan:\n%s", plan)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("got plan:\n%s", plan)
+		}
+	}
+}
+
+func TestReconcileLayoutNewItemsAndOrder(t *testing.T) {
+	f := newFakeOBS(t)
+	var mx sync.Mutex
+	created := false
+	f.handle("GetSceneList", func(map[string]any) (map[string]any, error) {
+		mx.Lock()
+		defer mx.Unlock()
+		items := []any{
+			map[string]any{"id": 1, "name": "Webcam", "type": "input"},
+			map[string]any{"id": 2, "name": "Chat", "type": "input"},
+			map[string]any{"id": 3, "name": "Logo", "type": "input"},
+		}
+		if created {
+			item := map[string]any{"id": 9, "name": "Title", "type": "input"}
+			items = append([]any{item}, items...)
+		}
+		return map[string]any{
+			"current-scene": "Main",
+			"scenes":        []any{map[string]any{"name": "Main", "sources": items}},
+		}, nil
+	})
+	f.reply("GetSourcesList", map[string]any{
+		"sources": []any{
+			map[string]any{"name": "Webcam", "typeId": "v4l2_input", "type": "input"},
+			map[string]any{"name": "Chat", "typeId": "browser_source", "type": "input"},
+			map[string]any{"name": "Logo", "typeId": "image_source", "type": "input"},
+		},
+	})
+	f.handle("CreateSource", func(map[string]any) (map[string]any, error) {
+		mx.Lock()
+		defer mx.Unlock()
+		created = true
+		return map[string]any{"itemId": 9}, nil
+	})
+	f.reply("SetSceneItemProperties", nil)
+	f.reply("ReorderSceneItems", nil)
+	c := f.connect()
+
+	x := 100.0
+	_, err := c.ReconcileLayout(obs.Layout{Scenes: []obs.LayoutScene{{
+		Name: "Main",
+		Items: []obs.LayoutItem{
+			{Source: "Logo"},
+			{Source: "Title", Kind: "text_ft2_source", Transform: &obs.ItemTransform{
+				Position: obs.SetSceneItemPropertiesPosition{X: &x},
+			}},
+			{Source: "Webcam"},
+		},
+	}}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The new item is updated by the ID it was created with.
+	props := f.sent("SetSceneItemProperties")
+	if len(props) != 1 {
+		t.Fatalf("%d property updates", len(props))
+	}
+	if item := props[0]["item"].(map[string]any); item["id"] != 9.0 || item["name"] != "Title" {
+		t.Errorf("updated item: %v", item)
+	}
+
+	// Chat is not in the layout, so it stays third from the top while the
+	// other items are reordered around it. The order is bottom to top.
+	reorder := f.sent("ReorderSceneItems")
+	if len(reorder) != 1 {
+		t.Fatalf("%d reorders", len(reorder))
+	}
+	var ids []float64
+	for _, v := range reorder[0]["items"].([]any) {
+		ids = append(ids, v.(map[string]any)["id"].(float64))
+	}
+	if want := []float64{1, 2, 9, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("order: %v, want %v", ids, want)
+	}
+}
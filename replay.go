@@ -0,0 +1,207 @@
+package go_obs
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// InstantReplay describes a sports-style instant replay: the replay buffer is
+// saved, played back with a media source in a replay scene, and the previous
+// scene returns when playback ends.
+type InstantReplay struct {
+	// Media source (`ffmpeg_source`) which plays the replay.
+	MediaSource string
+	// Scene which shows the media source.
+	Scene string
+	// How long to wait for the saved replay file to appear. Defaults to 10
+	// seconds.
+	SaveTimeout time.Duration
+}
+
+// ReplayResult describes a completed instant replay.
+type ReplayResult struct {
+	// Path of the saved replay file.
+	File string
+	// Scene which was shown before, and after, the replay.
+	PreviousScene string
+}
+
+// ReplayError is returned when a step of an instant replay fails.
+type ReplayError struct {
+	// Step which failed (eg. "save replay buffer").
+	Step string
+	Err  error
+}
+
+func (e *ReplayError) Error() string {
+	return "instant replay: " + e.Step + ": " + e.Err.Error()
+}
+
+func (e *ReplayError) Unwrap() error {
+	return e.Err
+}
+
+// ErrReplayBufferInactive is returned (wrapped in a ReplayError) when an
+// instant replay is started while the replay buffer is not running.
+var ErrReplayBufferInactive = errors.New("replay buffer is not active")
+
+// How often the recording folder is checked for the saved replay.
+const replayPollInterval = 250 * time.Millisecond
+
+// Function InstantReplay saves the replay buffer, loads the saved file into
+// the media source, switches to the replay scene, and switches back to the
+// previous scene when the media ends. It blocks until the previous scene is
+// back. If ctx is done during playback, the replay is cut short. Once the
+// replay scene has been shown, the previous scene is restored even if a
+// later step fails.
+//
+// The saved file is found by looking for the newest file in the recording
+// folder which matches the filename formatting, so OBS must run on the same
+// machine, or the recording folder must be mounted at the same path.
+func (c *Client) InstantReplay(ctx context.Context, r InstantReplay) (*ReplayResult, error) {
+	fail := func(step string, err error) (*ReplayResult, error) {
+		return nil, &ReplayError{step, err}
+	}
+
+	status, err := c.GetReplayBufferStatus()
+	if err != nil {
+		return fail("get replay buffer status", err)
+	}
+	if !status.IsReplayBufferActive {
+		return fail("get replay buffer status", ErrReplayBufferInactive)
+	}
+	current, err := c.GetCurrentScene()
+	if err != nil {
+		return fail("get current scene", err)
+	}
+	folder, err := c.GetRecordingFolder()
+	if err != nil {
+		return fail("get recording folder", err)
+	}
+	format, err := c.GetFilenameTemplate()
+	if err != nil {
+		return fail("get filename formatting", err)
+	}
+
+	// File times may have a coarse resolution, so allow some slack.
+	saved := time.Now().Add(-2 * time.Second)
+	if _, err := c.SaveReplayBuffer(); err != nil {
+		return fail("save replay buffer", err)
+	}
+	timeout := r.SaveTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	file, err := findReplayFile(ctx, folder.RecFolder, format, saved, timeout)
+	if err != nil {
+		return fail("locate replay file", err)
+	}
+	res := &ReplayResult{File: file, PreviousScene: current.Name}
+
+	ended, err := NewEventWaiter(c, func(e *MediaEndedEvent) bool {
+		return e.SourceName == r.MediaSource
+	})
+	if err != nil {
+		return fail("wait for media end", err)
+	}
+	defer ended.Cancel()
+	err = c.SetTypedSourceSettings(r.MediaSource, FFmpegSourceSettings{
+		IsLocalFile: ptr(true),
+		LocalFile:   ptr(file),
+		Looping:     ptr(false),
+	})
+	if err != nil {
+		return fail("load replay", err)
+	}
+	if _, err := c.SetCurrentScene(r.Scene); err != nil {
+		return fail("switch to replay scene", err)
+	}
+	if _, err := c.RestartMedia(r.MediaSource); err != nil {
+		c.SetCurrentScene(current.Name)
+		return fail("play replay", err)
+	}
+
+	_, waitErr := ended.Wait(ctx)
+	if _, err := c.SetCurrentScene(current.Name); err != nil {
+		return fail("return to previous scene", err)
+	}
+	if waitErr != nil {
+		return res, &ReplayError{"wait for media end", waitErr}
+	}
+	return res, nil
+}
+
+// Function findReplayFile waits for the newest file in folder which matches
+// the filename formatting, was modified after the given time, and is no
+// longer growing. Formatting which contains `/` saves into subfolders, so
+// files are looked for at that depth below the folder.
+func findReplayFile(ctx context.Context, folder string, format *FilenameTemplate, after time.Time, timeout time.Duration) (string, error) {
+	wait, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	pattern := format.Glob()
+	depth := strings.Count(pattern, "/")
+
+	var candidate string
+	var size int64 = -1
+	for {
+		var newest string
+		var newestInfo os.FileInfo
+		err := filepath.WalkDir(folder, func(p string, e fs.DirEntry, err error) error {
+			if err != nil {
+				if p == folder {
+					return err
+				}
+				return nil
+			}
+			rel, err := filepath.Rel(folder, p)
+			if err != nil || rel == "." {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if e.IsDir() {
+				if strings.Count(rel, "/") >= depth {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			stem := strings.TrimSuffix(rel, path.Ext(rel))
+			if ok, _ := path.Match(pattern, stem); !ok {
+				return nil
+			}
+			info, err := e.Info()
+			if err != nil || info.ModTime().Before(after) {
+				return nil
+			}
+			if newestInfo == nil || info.ModTime().After(newestInfo.ModTime()) {
+				newest, newestInfo = rel, info
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if newestInfo != nil {
+			// Only return the file once it has stopped growing.
+			if newest == candidate && newestInfo.Size() == size && size > 0 {
+				return filepath.Join(folder, filepath.FromSlash(newest)), nil
+			}
+			candidate, size = newest, newestInfo.Size()
+		}
+
+		select {
+		case <-wait.Done():
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			return "", errors.New("no replay file found in " + folder)
+		case <-time.After(replayPollInterval):
+		}
+	}
+}
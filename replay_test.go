@@ -0,0 +1,137 @@
+package go_obs_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestInstantReplaySubfolder(t *testing.T) {
+	dir := t.TempDir()
+	f := newFakeOBS(t)
+	f.reply("GetReplayBufferStatus", map[string]any{"isReplayBufferActive": true})
+	f.reply("GetCurrentScene", map[string]any{"name": "Main", "sources": []any{}})
+	f.reply("GetRecordingFolder", map[string]any{"rec-folder": dir})
+	f.reply("GetFilenameFormatting", map[string]any{"filename-formatting": "%CCYY-%MM/%DD/Replay %hh-%mm-%ss"})
+	f.handle("SaveReplayBuffer", func(map[string]any) (map[string]any, error) {
+		// A file in the folder itself, which does not match the depth of
+		// the formatting.
+		os.WriteFile(filepath.Join(dir, "Replay 10-07-30.mkv"), []byte("old"), 0644)
+		sub := filepath.Join(dir, "2024-05", "15")
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(filepath.Join(sub, "Replay 10-07-30.mkv"), []byte("replay"), 0644)
+	})
+	f.reply("SetSourceSettings", map[string]any{})
+	f.reply("SetCurrentScene", map[string]any{})
+	f.handle("RestartMedia", func(map[string]any) (map[string]any, error) {
+		go f.event("MediaEnded", map[string]any{"sourceName": "Replay Media"})
+		return nil, nil
+	})
+	c := f.connect()
+
+	res, err := c.InstantReplay(context.Background(), obs.InstantReplay{MediaSource: "Replay Media", Scene: "Replay"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(dir, "2024-05", "15", "Replay 10-07-30.mkv")
+	if res.File != want || res.PreviousScene != "Main" {
+		t.Errorf("got %+v, want %s", res, want)
+	}
+	loaded := f.sent("SetSourceSettings")
+	if len(loaded) != 1 || loaded[0]["sourceSettings"].(map[string]any)["local_file"] != want {
+		t.Errorf("loaded %v", loaded)
+	}
+}
+
+// Function fakeReplay answers the requests of an instant replay which saves
+// into dir. The media ends as soon as it is restarted.
+func fakeReplay(f *fakeOBS, dir string) {
+	f.reply("GetReplayBufferStatus", map[string]any{"isReplayBufferActive": true})
+	f.reply("GetCurrentScene", map[string]any{"name": "Main", "sources": []any{}})
+	f.reply("GetRecordingFolder", map[string]any{"rec-folder": dir})
+	f.reply("GetFilenameFormatting", map[string]any{"filename-formatting": "Replay %hh-%mm-%ss"})
+	f.handle("SaveReplayBuffer", func(map[string]any) (map[string]any, error) {
+		return nil, os.WriteFile(filepath.Join(dir, "Replay 10-07-30.mkv"), []byte("replay"), 0644)
+	})
+	f.reply("SetSourceSettings", map[string]any{})
+	f.reply("SetCurrentScene", map[string]any{})
+	f.handle("RestartMedia", func(map[string]any) (map[string]any, error) {
+		go f.event("MediaEnded", map[string]any{"sourceName": "Replay Media"})
+		return nil, nil
+	})
+}
+
+func TestInstantReplayInactive(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeReplay(f, t.TempDir())
+	f.reply("GetReplayBufferStatus", map[string]any{"isReplayBufferActive": false})
+	c := f.connect()
+
+	_, err := c.InstantReplay(context.Background(), obs.InstantReplay{MediaSource: "Replay Media", Scene: "Replay"})
+	if !errors.Is(err, obs.ErrReplayBufferInactive) {
+		t.Fatalf("got %v", err)
+	}
+	if n := len(f.sent("SaveReplayBuffer")); n != 0 {
+		t.Errorf("replay buffer saved %d times", n)
+	}
+}
+
+func TestInstantReplayFailures(t *testing.T) {
+	tests := []struct {
+		step    string
+		fail    string
+		restore bool
+	}{
+		{"save replay buffer", "SaveReplayBuffer", false},
+		{"load replay", "SetSourceSettings", false},
+		{"play replay", "RestartMedia", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fail, func(t *testing.T) {
+			f := newFakeOBS(t)
+			fakeReplay(f, t.TempDir())
+			f.handle(tt.fail, func(map[string]any) (map[string]any, error) {
+				return nil, errors.New("failed")
+			})
+			c := f.connect()
+
+			_, err := c.InstantReplay(context.Background(), obs.InstantReplay{MediaSource: "Replay Media", Scene: "Replay"})
+			var replayErr *obs.ReplayError
+			if !errors.As(err, &replayErr) || replayErr.Step != tt.step {
+				t.Fatalf("got %v", err)
+			}
+			var scenes []any
+			for _, req := range f.sent("SetCurrentScene") {
+				scenes = append(scenes, req["scene-name"])
+			}
+			if tt.restore && (len(scenes) != 2 || scenes[1] != "Main") {
+				t.Errorf("scene switches: %v", scenes)
+			}
+			if !tt.restore && len(scenes) != 0 {
+				t.Errorf("scene switches: %v", scenes)
+			}
+		})
+	}
+}
+
+func TestInstantReplayCancelWhileSaving(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeReplay(f, t.TempDir())
+	// The replay is never written.
+	f.reply("SaveReplayBuffer", nil)
+	c := f.connect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := c.InstantReplay(ctx, obs.InstantReplay{MediaSource: "Replay Media", Scene: "Replay"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+}
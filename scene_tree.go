@@ -0,0 +1,184 @@
+package go_obs
+
+import "errors"
+
+// SkipGroup can be returned from a SceneTree.Walk callback to skip the
+// children of the current group.
+var SkipGroup = errors.New("skip group")
+
+var errStopWalk = errors.New("stop walk")
+
+// SceneTree is the tree of items in a scene, including the items nested in
+// groups.
+type SceneTree struct {
+	// Name of the scene.
+	Scene string
+	// Items of the scene, top to bottom.
+	Items []SceneItem
+	// Source kinds by source name (eg. `ffmpeg_source`). May be nil.
+	Kinds map[string]string
+}
+
+// TreeItem is an item found in a scene tree.
+type TreeItem struct {
+	SceneItem
+	// Source kind (eg. `ffmpeg_source`), if known.
+	Kind string
+	// Names of the groups the item is nested in, outermost first. Empty for
+	// items directly in the scene.
+	Path []string
+}
+
+// Function Parent returns the name of the group the item belongs to, or an
+// empty string if it is directly in the scene.
+func (t TreeItem) Parent() string {
+	if len(t.Path) == 0 {
+		return ""
+	}
+	return t.Path[len(t.Path)-1]
+}
+
+// Function IsGroup returns whether the item is a group.
+func (t TreeItem) IsGroup() bool {
+	return t.Kind == "group" || t.Type == "group" || t.GroupChildren != nil
+}
+
+// Function NewSceneTree creates a scene tree from a scene in a GetSceneList
+// response. The kinds map, if not nil, provides the source kind of each
+// source by name, as in GetSourcesList.
+func NewSceneTree(scene Scene, kinds map[string]string) *SceneTree {
+	return &SceneTree{scene.Name, scene.Sources, kinds}
+}
+
+// Function NewSceneTreeFromItemList creates a scene tree from a
+// GetSceneItemList response. GetSceneItemList does not list the contents of
+// groups, so groups in the tree have no children.
+func NewSceneTreeFromItemList(res *GetSceneItemListResponse) *SceneTree {
+	tree := &SceneTree{Scene: res.SceneName, Kinds: make(map[string]string)}
+	for _, v := range res.SceneItems {
+		tree.Items = append(tree.Items, SceneItem{
+			Id:   v.ItemId,
+			Name: v.SourceName,
+			Type: v.SourceType,
+		})
+		tree.Kinds[v.SourceName] = v.SourceKind
+	}
+	return tree
+}
+
+// Function GetSceneTree fetches the tree of items in a scene, with the
+// source kind of each item. An empty scene name gets the current scene.
+func (c *Client) GetSceneTree(sceneName string) (*SceneTree, error) {
+	var tree *SceneTree
+	if sceneName == "" {
+		res, err := c.GetCurrentScene()
+		if err != nil {
+			return nil, err
+		}
+		tree = &SceneTree{Scene: res.Name, Items: res.Sources}
+	} else {
+		res, err := c.GetSceneList()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range res.Scenes {
+			if v.Name == sceneName {
+				tree = NewSceneTree(v, nil)
+				break
+			}
+		}
+		if tree == nil {
+			return nil, errors.New("no scene: " + sceneName)
+		}
+	}
+
+	sources, err := c.GetSourcesList()
+	if err != nil {
+		return nil, err
+	}
+	tree.Kinds = make(map[string]string)
+	for _, v := range sources.Sources {
+		tree.Kinds[v.Name] = v.TypeId
+	}
+	return tree, nil
+}
+
+// Function Walk calls fn for every item in the tree, depth first, with
+// groups visited before their children. If fn returns SkipGroup for a group,
+// its children are skipped; any other error stops the walk and is returned.
+func (t *SceneTree) Walk(fn func(item TreeItem) error) error {
+	return t.walk(t.Items, nil, fn)
+}
+
+func (t *SceneTree) walk(items []SceneItem, path []string, fn func(TreeItem) error) error {
+	for _, v := range items {
+		item := TreeItem{SceneItem: v, Kind: t.Kinds[v.Name], Path: path}
+		if err := fn(item); err == SkipGroup {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if len(v.GroupChildren) > 0 {
+			// Copy the path so that items passed to fn keep their own.
+			sub := append(path[:len(path):len(path)], v.Name)
+			if err := t.walk(v.GroupChildren, sub, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Function Find returns the first item, depth first, for which match
+// returns true.
+func (t *SceneTree) Find(match func(item TreeItem) bool) (TreeItem, bool) {
+	var found TreeItem
+	var ok bool
+	t.Walk(func(item TreeItem) error {
+		if match(item) {
+			found, ok = item, true
+			return errStopWalk
+		}
+		return nil
+	})
+	return found, ok
+}
+
+// Function FindByName returns the first item with the given source name.
+func (t *SceneTree) FindByName(name string) (TreeItem, bool) {
+	return t.Find(func(item TreeItem) bool {
+		return item.Name == name
+	})
+}
+
+// Function FindById returns the item with the given scene item ID. Since IDs
+// are only unique within a scene or group, the first match depth first is
+// returned.
+func (t *SceneTree) FindById(id int) (TreeItem, bool) {
+	return t.Find(func(item TreeItem) bool {
+		return item.Id == id
+	})
+}
+
+// Function FindByKind returns every item whose source is of the given kind.
+func (t *SceneTree) FindByKind(kind string) []TreeItem {
+	var out []TreeItem
+	t.Walk(func(item TreeItem) error {
+		if item.Kind == kind {
+			out = append(out, item)
+		}
+		return nil
+	})
+	return out
+}
+
+// Function Flatten returns every item in the tree, depth first, with groups
+// before their children.
+func (t *SceneTree) Flatten() []TreeItem {
+	var out []TreeItem
+	t.Walk(func(item TreeItem) error {
+		out = append(out, item)
+		return nil
+	})
+	return out
+}
@@ -0,0 +1,86 @@
+package go_obs_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestSceneTree(t *testing.T) {
+	tree := obs.NewSceneTree(obs.Scene{
+		Name: "Main",
+		Sources: []obs.SceneItem{
+			{Name: "Overlay", Id: 1},
+			{Name: "Cameras", Id: 2, Type: "group", GroupChildren: []obs.SceneItem{
+				{Name: "Cam 1", Id: 3, ParentGroupName: "Cameras"},
+				{Name: "Inner", Id: 4, Type: "group", ParentGroupName: "Cameras", GroupChildren: []obs.SceneItem{
+					{Name: "Cam 2", Id: 5, ParentGroupName: "Inner"},
+				}},
+			}},
+			{Name: "Background", Id: 6},
+		},
+	}, map[string]string{
+		"Overlay": "browser_source",
+		"Cam 1":   "v4l2_input",
+		"Cam 2":   "v4l2_input",
+	})
+
+	var names []string
+	for _, v := range tree.Flatten() {
+		names = append(names, v.Name)
+	}
+	want := []string{"Overlay", "Cameras", "Cam 1", "Inner", "Cam 2", "Background"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("flatten: got %v, want %v", names, want)
+	}
+
+	item, ok := tree.FindByName("Cam 2")
+	if !ok || item.Id != 5 || item.Parent() != "Inner" || !reflect.DeepEqual(item.Path, []string{"Cameras", "Inner"}) {
+		t.Errorf("find by name: %+v", item)
+	}
+	if item, ok := tree.FindById(4); !ok || item.Name != "Inner" || !item.IsGroup() {
+		t.Errorf("find by id: %+v", item)
+	}
+	if _, ok := tree.FindById(42); ok {
+		t.Error("found missing id")
+	}
+	if items := tree.FindByKind("v4l2_input"); len(items) != 2 || items[0].Name != "Cam 1" || items[1].Name != "Cam 2" {
+		t.Errorf("find by kind: %+v", items)
+	}
+
+	names = nil
+	tree.Walk(func(item obs.TreeItem) error {
+		names = append(names, item.Name)
+		if item.Name == "Cameras" {
+			return obs.SkipGroup
+		}
+		return nil
+	})
+	want = []string{"Overlay", "Cameras", "Background"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("skip group: got %v, want %v", names, want)
+	}
+}
+
+func TestSceneTreeFromItemList(t *testing.T) {
+	res := &obs.GetSceneItemListResponse{}
+	err := json.Unmarshal([]byte(`{"sceneName":"Main","sceneItems":[
+		{"itemId":1,"sourceKind":"browser_source","sourceName":"Overlay","sourceType":"input"},
+		{"itemId":2,"sourceKind":"group","sourceName":"Cameras","sourceType":"group"}
+	]}`), res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := obs.NewSceneTreeFromItemList(res)
+	if tree.Scene != "Main" || len(tree.Flatten()) != 2 {
+		t.Fatalf("tree: %+v", tree)
+	}
+	if item, ok := tree.FindById(1); !ok || item.Name != "Overlay" || item.Kind != "browser_source" {
+		t.Errorf("find by id: %+v", item)
+	}
+	if item, ok := tree.FindByName("Cameras"); !ok || !item.IsGroup() {
+		t.Errorf("find group: %+v", item)
+	}
+}
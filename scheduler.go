@@ -0,0 +1,457 @@
+package go_obs
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule decides when a scheduled job runs.
+type Schedule interface {
+	// Function Next returns the first time after the given time at which
+	// the job should run, or the zero time if it should not run again.
+	Next(after time.Time) time.Time
+}
+
+type atSchedule time.Time
+
+func (s atSchedule) Next(after time.Time) time.Time {
+	if t := time.Time(s); t.After(after) {
+		return t
+	}
+	return time.Time{}
+}
+
+// Function At returns a schedule which runs once at the given time.
+func At(t time.Time) Schedule {
+	return atSchedule(t)
+}
+
+// Function After returns a schedule which runs once after the given delay
+// from now.
+func After(d time.Duration) Schedule {
+	return atSchedule(time.Now().Add(d))
+}
+
+type everySchedule time.Duration
+
+func (s everySchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(s))
+}
+
+// Function Every returns a schedule which runs repeatedly with the given
+// interval.
+func Every(d time.Duration) Schedule {
+	return everySchedule(d)
+}
+
+// CronSchedule is a schedule in the format of a crontab line: minute, hour,
+// day of month, month and day of week. Each field is `*`, a number, a range
+// (`1-5`), a step (`*/15`, `0-30/10`) or a comma separated list of those.
+// Days of the week are 0-6 starting on Sunday, or 7 for Sunday. Times are
+// in the location of the time passed to Next.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// Whether the day of month and day of week fields were restricted.
+	domStar, dowStar bool
+}
+
+// Function ParseCron parses a schedule in the crontab format.
+func ParseCron(spec string) (*CronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.New("cron schedule must have 5 fields: " + spec)
+	}
+	s := &CronSchedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	out := [5]*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, f := range fields {
+		bits, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, errors.New("cron field " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		*out[i] = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, errors.New("invalid step: " + part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			r := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(r[0]); err != nil {
+				return 0, errors.New("invalid value: " + part)
+			}
+			hi = lo
+			if len(r) == 2 {
+				if hi, err = strconv.Atoi(r[1]); err != nil {
+					return 0, errors.New("invalid value: " + part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, errors.New("value out of range: " + part)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	// Give up if nothing matches within five years (eg. February 30th).
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<int(t.Month())) == 0 {
+			t = wallClock(t.Year(), t.Month()+1, 1, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = wallClock(t.Year(), t.Month(), t.Day()+1, 0, loc)
+			continue
+		}
+		if s.hour&(1<<t.Hour()) == 0 {
+			t = wallClock(t.Year(), t.Month(), t.Day(), t.Hour()+1, loc)
+			continue
+		}
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Function wallClock returns the first time in loc at or after the start of
+// the given hour on the local clock. Unlike time.Date, it doesn't go back to
+// before a clock change when the hour was skipped by it, and it works in
+// zones which are not offset from UTC by whole hours.
+func wallClock(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+	want := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	t := time.Date(year, month, day, hour, 0, 0, 0, loc)
+	for {
+		wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+		if !wall.Before(want) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+}
+
+// MissedRunPolicy decides what happens to runs of a job which were due while
+// the client was not connected.
+type MissedRunPolicy int
+
+const (
+	// Missed runs are skipped.
+	MissedSkip MissedRunPolicy = iota
+	// The job runs once as soon as the client is connected again, however
+	// many runs were missed.
+	MissedRunOnce
+)
+
+// Job is an action to run on a schedule.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Action   func(c *Client) error
+	Missed   MissedRunPolicy
+}
+
+// JobInfo describes a job added to a scheduler.
+type JobInfo struct {
+	Id   int
+	Name string
+	// Next time the job runs, or the zero time if it has a missed run
+	// waiting for the client to connect.
+	Next time.Time
+	// Whether a missed run is waiting for the client to connect.
+	Missed bool
+}
+
+type scheduledJob struct {
+	Job
+	id      int
+	next    time.Time
+	missed  bool
+	running bool
+}
+
+// Scheduler runs jobs at absolute times, after delays, or on repeating or
+// cron schedules. Jobs are kept in memory only.
+type Scheduler struct {
+	c       *Client
+	mx      sync.Mutex
+	jobs    map[int]*scheduledJob
+	nextJob int
+	onError map[int]func(job string, err error)
+	onRun   map[int]func(job string)
+	nextId  int
+	wake    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// How often a scheduler checks whether the client has reconnected while
+// missed runs are waiting.
+const reconnectInterval = time.Second
+
+// Function NewScheduler creates a scheduler which runs jobs with the given
+// client.
+func NewScheduler(c *Client) *Scheduler {
+	s := &Scheduler{
+		c:       c,
+		jobs:    make(map[int]*scheduledJob),
+		onError: make(map[int]func(string, error)),
+		onRun:   make(map[int]func(string)),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Function Close stops the scheduler. Running jobs are not interrupted.
+func (s *Scheduler) Close() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// Function Add adds a job and returns its ID. Jobs whose schedule has no
+// upcoming run are ignored and return -1.
+func (s *Scheduler) Add(job Job) int {
+	next := job.Schedule.Next(time.Now())
+	if next.IsZero() {
+		return -1
+	}
+	s.mx.Lock()
+	id := s.nextJob
+	s.nextJob++
+	s.jobs[id] = &scheduledJob{Job: job, id: id, next: next}
+	s.mx.Unlock()
+	s.notify()
+	return id
+}
+
+// Function Remove removes a job. It returns false if no job has the given
+// ID.
+func (s *Scheduler) Remove(id int) bool {
+	s.mx.Lock()
+	_, ok := s.jobs[id]
+	delete(s.jobs, id)
+	s.mx.Unlock()
+	s.notify()
+	return ok
+}
+
+// Function Jobs returns the jobs in the scheduler, ordered by their next
+// run.
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mx.Lock()
+	out := make([]JobInfo, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		out = append(out, JobInfo{Id: j.id, Name: j.Name, Next: j.next, Missed: j.missed})
+	}
+	s.mx.Unlock()
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Next.Equal(out[b].Next) {
+			return out[a].Id < out[b].Id
+		}
+		return out[a].Next.Before(out[b].Next)
+	})
+	return out
+}
+
+// Function OnError registers fn to be called whenever a job fails, or is
+// skipped because the client is not connected. The returned function
+// unregisters the callback.
+func (s *Scheduler) OnError(fn func(job string, err error)) func() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	id := s.nextId
+	s.nextId++
+	s.onError[id] = fn
+	return func() {
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		delete(s.onError, id)
+	}
+}
+
+// Function OnRun registers fn to be called whenever a job completes
+// successfully. The returned function unregisters the callback.
+func (s *Scheduler) OnRun(fn func(job string)) func() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	id := s.nextId
+	s.nextId++
+	s.onRun[id] = fn
+	return func() {
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		delete(s.onRun, id)
+	}
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		wait := s.tick(time.Now())
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// Function tick starts every job which is due, and returns the time until
+// the next one is.
+func (s *Scheduler) tick(now time.Time) time.Duration {
+	connected := s.c.Connected()
+	wait := time.Hour
+
+	s.mx.Lock()
+	var due []*scheduledJob
+	var missed []string
+	for id, j := range s.jobs {
+		run := false
+		if j.missed && connected {
+			j.missed = false
+			run = true
+		}
+		if !j.next.IsZero() && !j.next.After(now) {
+			if connected {
+				run = true
+			} else {
+				missed = append(missed, j.Name)
+				j.missed = j.Missed == MissedRunOnce
+			}
+			j.next = j.Schedule.Next(now)
+		}
+		if run {
+			due = append(due, j)
+		}
+
+		if j.next.IsZero() && !j.missed {
+			delete(s.jobs, id)
+			continue
+		}
+		if j.missed {
+			wait = minDuration(wait, reconnectInterval)
+		}
+		if !j.next.IsZero() {
+			wait = minDuration(wait, j.next.Sub(now))
+		}
+	}
+	s.mx.Unlock()
+
+	for _, name := range missed {
+		s.report(name, errors.New("missed run: client not connected"))
+	}
+	for _, j := range due {
+		go s.runJob(j)
+	}
+	return wait
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (s *Scheduler) runJob(j *scheduledJob) {
+	s.mx.Lock()
+	if j.running {
+		// Don't overlap runs of the same job.
+		s.mx.Unlock()
+		s.report(j.Name, errors.New("skipped run: previous run still in progress"))
+		return
+	}
+	j.running = true
+	s.mx.Unlock()
+
+	err := j.Action(s.c)
+
+	s.mx.Lock()
+	j.running = false
+	fns := make([]func(string), 0, len(s.onRun))
+	for _, fn := range s.onRun {
+		fns = append(fns, fn)
+	}
+	s.mx.Unlock()
+	if err != nil {
+		s.report(j.Name, err)
+		return
+	}
+	for _, fn := range fns {
+		fn(j.Name)
+	}
+}
+
+func (s *Scheduler) report(job string, err error) {
+	s.mx.Lock()
+	fns := make([]func(string, error), 0, len(s.onError))
+	for _, fn := range s.onError {
+		fns = append(fns, fn)
+	}
+	s.mx.Unlock()
+	for _, fn := range fns {
+		fn(job, err)
+	}
+}
@@ -0,0 +1,88 @@
+package go_obs_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestCronSchedule(t *testing.T) {
+	// Wednesday.
+	from := time.Date(2024, 5, 15, 10, 7, 30, 0, time.UTC)
+	cases := map[string]time.Time{
+		"* * * * *":       time.Date(2024, 5, 15, 10, 8, 0, 0, time.UTC),
+		"*/15 * * * *":    time.Date(2024, 5, 15, 10, 15, 0, 0, time.UTC),
+		"0 9 * * *":       time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC),
+		"30 20 * * 1-5":   time.Date(2024, 5, 15, 20, 30, 0, 0, time.UTC),
+		"0 12 * * 0":      time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC),
+		"0 12 * * 7":      time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC),
+		"0 0 1 1 *":       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":      time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		"5,10 8-9 20 * 5": time.Date(2024, 5, 17, 8, 5, 0, 0, time.UTC),
+	}
+	for spec, want := range cases {
+		s, err := obs.ParseCron(spec)
+		if err != nil {
+			t.Errorf("%q: %s", spec, err)
+			continue
+		}
+		if got := s.Next(from); !got.Equal(want) {
+			t.Errorf("%q: got %s, want %s", spec, got, want)
+		}
+	}
+
+	if s, err := obs.ParseCron("0 0 30 2 *"); err != nil || !s.Next(from).IsZero() {
+		t.Errorf("impossible schedule: %v", err)
+	}
+	for _, spec := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		if _, err := obs.ParseCron(spec); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+func TestCronScheduleZones(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		spec       string
+		from, want time.Time
+	}{
+		// UTC+5:30.
+		{"0 11 * * *", time.Date(2024, 5, 15, 10, 7, 0, 0, kolkata), time.Date(2024, 5, 15, 11, 0, 0, 0, kolkata)},
+		{"0 * * * *", time.Date(2024, 5, 15, 10, 7, 0, 0, kolkata), time.Date(2024, 5, 15, 11, 0, 0, 0, kolkata)},
+		{"0 9 * * *", time.Date(2024, 5, 15, 10, 7, 0, 0, kolkata), time.Date(2024, 5, 16, 9, 0, 0, 0, kolkata)},
+		// Clocks go forward from 2:00 to 3:00 on March 10th.
+		{"30 3 * * *", time.Date(2024, 3, 10, 0, 10, 0, 0, newYork), time.Date(2024, 3, 10, 3, 30, 0, 0, newYork)},
+		{"0 * * * *", time.Date(2024, 3, 10, 1, 10, 0, 0, newYork), time.Date(2024, 3, 10, 3, 0, 0, 0, newYork)},
+		{"0 2 * * *", time.Date(2024, 3, 9, 23, 0, 0, 0, newYork), time.Date(2024, 3, 11, 2, 0, 0, 0, newYork)},
+		// Clocks go back from 2:00 to 1:00 on November 3rd, so 1:00 happens
+		// twice, an hour apart.
+		{"0 * * * *", time.Date(2024, 11, 3, 1, 0, 0, 0, newYork), time.Date(2024, 11, 3, 1, 0, 0, 0, newYork).Add(time.Hour)},
+		{"0 3 * * *", time.Date(2024, 11, 3, 0, 10, 0, 0, newYork), time.Date(2024, 11, 3, 3, 0, 0, 0, newYork)},
+		// Clocks go forward from midnight to 1:00 on September 8th.
+		{"0 12 * * *", time.Date(2024, 9, 7, 13, 0, 0, 0, santiago), time.Date(2024, 9, 8, 12, 0, 0, 0, santiago)},
+		{"0 0 * * *", time.Date(2024, 9, 7, 13, 0, 0, 0, santiago), time.Date(2024, 9, 9, 0, 0, 0, 0, santiago)},
+	}
+	for _, c := range cases {
+		s, err := obs.ParseCron(c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Next(c.from); !got.Equal(c.want) {
+			t.Errorf("%q after %s: got %s, want %s", c.spec, c.from, got, c.want)
+		}
+	}
+}
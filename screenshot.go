@@ -0,0 +1,135 @@
+package go_obs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+)
+
+// Function DecodeDataURI decodes a base64 encoded data URI, such as the one
+// returned by TakeSourceScreenshot, into its raw bytes and MIME type.
+func DecodeDataURI(uri string) ([]byte, string, error) {
+	if !strings.HasPrefix(uri, "data:") {
+		return nil, "", errors.New("not a data uri")
+	}
+	header, payload, ok := strings.Cut(uri[len("data:"):], ",")
+	if !ok {
+		return nil, "", errors.New("malformed data uri")
+	}
+	mime, encoding, _ := strings.Cut(header, ";")
+	if encoding != "base64" {
+		return nil, "", errors.New("data uri is not base64 encoded")
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, mime, nil
+}
+
+// Function Data returns the raw bytes and MIME type of the embedded
+// screenshot.
+func (r *TakeSourceScreenshotResponse) Data() ([]byte, string, error) {
+	if r.Img == "" {
+		return nil, "", errors.New("no embedded image")
+	}
+	return DecodeDataURI(r.Img)
+}
+
+// Function Image decodes the embedded screenshot. Only PNG and JPEG images
+// can be decoded.
+func (r *TakeSourceScreenshotResponse) Image() (image.Image, error) {
+	data, _, err := r.Data()
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// Function ScreenshotData takes a screenshot of the given source (or the
+// current scene, if empty) in the given format and returns its raw bytes and
+// MIME type. The width and height may be nil to use the source's base size.
+func (c *Client) ScreenshotData(sourceName string, format string, width *int, height *int) ([]byte, string, error) {
+	if err := c.checkImageFormat(format); err != nil {
+		return nil, "", err
+	}
+	res, err := c.TakeSourceScreenshot(sourceName, format, "", "", nil, width, height)
+	if err != nil {
+		return nil, "", err
+	}
+	return res.Data()
+}
+
+// Function ScreenshotImage takes a PNG screenshot of the given source (or
+// the current scene, if empty) and decodes it.
+func (c *Client) ScreenshotImage(sourceName string, width *int, height *int) (image.Image, error) {
+	if err := c.checkImageFormat("png"); err != nil {
+		return nil, err
+	}
+	res, err := c.TakeSourceScreenshot(sourceName, "png", "", "", nil, width, height)
+	if err != nil {
+		return nil, err
+	}
+	return res.Image()
+}
+
+// Function WriteScreenshot takes a screenshot of the given source (or the
+// current scene, if empty) in the given format and writes it to w.
+func (c *Client) WriteScreenshot(w io.Writer, sourceName string, format string, width *int, height *int) error {
+	data, _, err := c.ScreenshotData(sourceName, format, width, height)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Function ImageExportFormats returns the image formats which OBS supports
+// for screenshots. The list is fetched with GetVersion once per connection.
+func (c *Client) ImageExportFormats() ([]string, error) {
+	c.mx.Lock()
+	formats := c.imageFormats
+	c.mx.Unlock()
+	if formats != nil {
+		return formats, nil
+	}
+
+	res, err := c.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+	formats = []string{}
+	for _, f := range strings.Split(res.SupportedImageExportFormats, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			formats = append(formats, strings.ToLower(f))
+		}
+	}
+
+	c.mx.Lock()
+	c.imageFormats = formats
+	c.mx.Unlock()
+	return formats, nil
+}
+
+func (c *Client) checkImageFormat(format string) error {
+	if format == "" {
+		return errors.New("no image format")
+	}
+	formats, err := c.ImageExportFormats()
+	if err != nil {
+		return err
+	}
+	format = strings.ToLower(format)
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+	return errors.New("unsupported image format: " + format)
+}
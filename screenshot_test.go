@@ -0,0 +1,56 @@
+package go_obs_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestDecodeDataURI(t *testing.T) {
+	data, mime, err := obs.DecodeDataURI("data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte("hello")))
+	if err != nil || string(data) != "hello" || mime != "text/plain" {
+		t.Errorf("got %q, %q, %v", data, mime, err)
+	}
+	for _, uri := range []string{
+		"",
+		"hello",
+		"data:text/plain;base64",
+		"data:text/plain,hello",
+		"data:text/plain;base64,!!!",
+	} {
+		if _, _, err := obs.DecodeDataURI(uri); err == nil {
+			t.Errorf("%q: no error", uri)
+		}
+	}
+}
+
+func TestScreenshotImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(1, 1, color.RGBA{255, 0, 0, 255})
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	res := &obs.TakeSourceScreenshotResponse{
+		Img: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}
+	decoded, err := res.Image()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("bounds: %v", decoded.Bounds())
+	}
+	if r, _, _, _ := decoded.At(1, 1).RGBA(); r != 0xffff {
+		t.Errorf("pixel: %v", decoded.At(1, 1))
+	}
+
+	if _, err := (&obs.TakeSourceScreenshotResponse{}).Image(); err == nil {
+		t.Error("decoded an empty response")
+	}
+}
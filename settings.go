@@ -0,0 +1,85 @@
+package go_obs
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Function remarshal converts an untyped value, such as the settings
+// object of a response, into the given typed value by way of JSON.
+func remarshal(in any, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// Function marshalSettings encodes a settings struct, merging in any keys
+// from extra which the struct does not know about.
+func marshalSettings(v any, extra map[string]any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	m := make(map[string]any)
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	known := settingsKeys(reflect.TypeOf(v))
+	for k, val := range extra {
+		if _, ok := known[k]; !ok {
+			m[k] = val
+		}
+	}
+	return json.Marshal(m)
+}
+
+// Function unmarshalSettings decodes a settings struct, storing any keys
+// which the struct does not know about in extra.
+func unmarshalSettings(data []byte, v any, extra *map[string]any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	known := settingsKeys(reflect.TypeOf(v))
+	for k := range known {
+		delete(m, k)
+	}
+	if len(m) == 0 {
+		*extra = nil
+	} else {
+		*extra = m
+	}
+	return nil
+}
+
+// Function settingsKeys returns the set of JSON keys used by the fields of
+// the given struct type.
+func settingsKeys(t reflect.Type) map[string]struct{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	keys := make(map[string]struct{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		keys[name] = struct{}{}
+	}
+	return keys
+}
+
+// Function ptr returns a pointer to a copy of v, for filling in optional
+// fields.
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,520 @@
+package go_obs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// The version of the CollectionSnapshot document format written by
+// ExportCollection.
+const CollectionSnapshotVersion = 1
+
+// CollectionSnapshot is a versioned document describing the scenes,
+// sources, filters and audio settings of a scene collection, which can be
+// stored as JSON and later restored with ImportCollection.
+type CollectionSnapshot struct {
+	// Document format version.
+	Version int `json:"version"`
+	// Time the snapshot was taken.
+	Created time.Time `json:"created"`
+	// Name of the scene collection.
+	SceneCollection string `json:"sceneCollection"`
+	// Name of the current scene.
+	CurrentScene string `json:"currentScene"`
+	// Name of the current transition.
+	CurrentTransition string `json:"currentTransition"`
+	// Duration of the current transition (in milliseconds).
+	TransitionDuration int `json:"transitionDuration"`
+	// All sources other than scenes.
+	Sources []SnapshotSource `json:"sources"`
+	// Ordered list of scenes.
+	Scenes []SnapshotScene `json:"scenes"`
+}
+
+// A source of a CollectionSnapshot.
+type SnapshotSource struct {
+	// Source name.
+	Name string `json:"name"`
+	// Source kind (eg. `ffmpeg_source`).
+	Kind string `json:"kind"`
+	// Source type (eg. `input`).
+	Type string `json:"type"`
+	// Source settings.
+	Settings interface{} `json:"settings,omitempty"`
+	// Ordered list of the source's filters.
+	Filters []SnapshotFilter `json:"filters,omitempty"`
+	// Audio settings, if the source has audio.
+	Audio *SnapshotAudio `json:"audio,omitempty"`
+}
+
+// A filter of a CollectionSnapshot.
+type SnapshotFilter struct {
+	// Filter name.
+	Name string `json:"name"`
+	// Filter kind (eg. `gain_filter`).
+	Kind string `json:"kind"`
+	// Whether the filter is enabled.
+	Enabled bool `json:"enabled"`
+	// Filter settings.
+	Settings interface{} `json:"settings,omitempty"`
+}
+
+// Audio settings of a source of a CollectionSnapshot.
+type SnapshotAudio struct {
+	// Volume as a multiplier.
+	Volume float64 `json:"volume"`
+	// Whether the source is muted.
+	Muted bool `json:"muted"`
+	// Audio sync offset (in nanoseconds).
+	SyncOffset int `json:"syncOffset"`
+	// Monitor type. Options: `none`, `monitorOnly`, `monitorAndOutput`.
+	MonitorType string `json:"monitorType"`
+	// Whether each of the audio tracks 1-6 is active.
+	Tracks [6]bool `json:"tracks"`
+}
+
+// A scene of a CollectionSnapshot.
+type SnapshotScene struct {
+	// Scene name.
+	Name string `json:"name"`
+	// Transition override used when switching to the scene, if any.
+	TransitionOverride *SnapshotTransitionOverride `json:"transitionOverride,omitempty"`
+	// Items of the scene, from top to bottom.
+	Items []SnapshotItem `json:"items"`
+}
+
+// A scene transition override of a CollectionSnapshot.
+type SnapshotTransitionOverride struct {
+	// Name of the transition.
+	Name string `json:"name"`
+	// Transition duration (in milliseconds).
+	Duration int `json:"duration"`
+}
+
+// A scene item of a CollectionSnapshot.
+type SnapshotItem struct {
+	// Name of the item's source.
+	Source string `json:"source"`
+	// Scene item ID at the time of the snapshot.
+	Id int `json:"id"`
+	// Whether the item is visible.
+	Visible bool `json:"visible"`
+	// Whether the item is locked.
+	Locked bool `json:"locked"`
+	// Whether the item is a group.
+	Group bool `json:"group,omitempty"`
+	// The item's transform.
+	Transform ItemTransform `json:"transform"`
+	// Items of the group, from top to bottom, if the item is a group.
+	Children []SnapshotItem `json:"children,omitempty"`
+}
+
+// Function ReadCollectionSnapshot reads a JSON encoded snapshot, checking
+// that its version is supported.
+func ReadCollectionSnapshot(r io.Reader) (*CollectionSnapshot, error) {
+	s := &CollectionSnapshot{}
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+	if err := s.checkVersion(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Function Write writes the snapshot to w as indented JSON.
+func (s *CollectionSnapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+func (s *CollectionSnapshot) checkVersion() error {
+	if s.Version < 1 || s.Version > CollectionSnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	return nil
+}
+
+// Function ExportCollection takes a snapshot of the current scene
+// collection, including the contents of groups.
+func (c *Client) ExportCollection() (*CollectionSnapshot, error) {
+	s := &CollectionSnapshot{
+		Version: CollectionSnapshotVersion,
+		Created: time.Now(),
+	}
+
+	collection, err := c.GetCurrentSceneCollection()
+	if err != nil {
+		return nil, err
+	}
+	s.SceneCollection = collection.ScName
+
+	transition, err := c.GetTransitionList()
+	if err != nil {
+		return nil, err
+	}
+	s.CurrentTransition = transition.CurrentTransition
+	duration, err := c.GetTransitionDuration()
+	if err != nil {
+		return nil, err
+	}
+	s.TransitionDuration = duration.TransitionDuration
+
+	sources, audioKinds, err := c.getSourcesWithAudio()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range sources.Sources {
+		src, err := c.exportSource(v.Name, v.TypeId, v.Type, audioKinds[v.TypeId])
+		if err != nil {
+			return nil, err
+		}
+		s.Sources = append(s.Sources, src)
+	}
+
+	scenes, err := c.GetSceneList()
+	if err != nil {
+		return nil, err
+	}
+	s.CurrentScene = scenes.CurrentScene
+	for _, v := range scenes.Scenes {
+		sc, err := c.exportScene(v)
+		if err != nil {
+			return nil, err
+		}
+		s.Scenes = append(s.Scenes, sc)
+	}
+	return s, nil
+}
+
+func (c *Client) exportSource(name, kind, typ string, audio bool) (SnapshotSource, error) {
+	src := SnapshotSource{Name: name, Kind: kind, Type: typ}
+
+	settings, err := c.GetSourceSettings(name, "")
+	if err != nil {
+		return src, err
+	}
+	src.Settings = settings.SourceSettings
+
+	filters, err := c.GetSourceFilters(name)
+	if err != nil {
+		return src, err
+	}
+	for _, f := range filters.Filters {
+		src.Filters = append(src.Filters, SnapshotFilter{
+			Name:     f.Name,
+			Kind:     f.Type,
+			Enabled:  f.Enabled,
+			Settings: f.Settings,
+		})
+	}
+
+	if !audio {
+		return src, nil
+	}
+	ch, err := c.loadAudioChannel(name, kind)
+	if err != nil {
+		return src, err
+	}
+	src.Audio = &SnapshotAudio{
+		Volume:      ch.Volume,
+		Muted:       ch.Muted,
+		SyncOffset:  int(ch.SyncOffset),
+		MonitorType: ch.MonitorType,
+		Tracks:      ch.Tracks,
+	}
+	return src, nil
+}
+
+func (c *Client) exportScene(scene Scene) (SnapshotScene, error) {
+	sc := SnapshotScene{Name: scene.Name, Items: []SnapshotItem{}}
+
+	override, err := c.GetSceneTransitionOverride(scene.Name)
+	if err != nil {
+		return sc, err
+	}
+	if override.TransitionName != "" {
+		sc.TransitionOverride = &SnapshotTransitionOverride{
+			Name:     override.TransitionName,
+			Duration: override.TransitionDuration,
+		}
+	}
+
+	sc.Items, err = c.exportItems(scene.Name, scene.Sources)
+	return sc, err
+}
+
+// Function exportItems records the items of a scene or group. Group
+// children are read from their group, which obs-websocket treats like a
+// scene.
+func (c *Client) exportItems(scene string, items []SceneItem) ([]SnapshotItem, error) {
+	out := []SnapshotItem{}
+	for _, v := range items {
+		props, err := c.GetSceneItemProperties(scene, ItemById(v.Id))
+		if err != nil {
+			return nil, err
+		}
+		item := SnapshotItem{
+			Source:    v.Name,
+			Id:        v.Id,
+			Visible:   props.Visible,
+			Locked:    props.Locked,
+			Group:     v.Type == "group",
+			Transform: ItemTransformFromProperties(props),
+		}
+		if item.Group {
+			if item.Children, err = c.exportItems(v.Name, v.GroupChildren); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// Function ImportCollection makes the current scene collection match the
+// snapshot, creating missing scenes, sources, items and filters, updating
+// existing ones, and switching to the snapshot's current scene. Nothing is
+// removed. Sources which are not used by any scene, and groups, can only be
+// updated if they already exist; the items of existing groups are updated,
+// but missing ones are not added. If dryRun is set, the plan is returned
+// without being applied.
+func (c *Client) ImportCollection(s *CollectionSnapshot, dryRun bool) (*Plan, error) {
+	if err := s.checkVersion(); err != nil {
+		return nil, err
+	}
+
+	list, err := c.GetSourcesList()
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool)
+	for _, v := range list.Sources {
+		exists[v.Name] = true
+	}
+	sources := make(map[string]SnapshotSource)
+	for _, v := range s.Sources {
+		sources[v.Name] = v
+	}
+
+	layout := Layout{}
+	placed := make(map[string]bool)
+	for _, sc := range s.Scenes {
+		ls := LayoutScene{Name: sc.Name}
+		for _, item := range sc.Items {
+			if item.Group && !exists[item.Source] {
+				continue
+			}
+			t := item.Transform
+			li := LayoutItem{
+				Source:    item.Source,
+				Transform: &t,
+				Visible:   ptr(item.Visible),
+				Locked:    ptr(item.Locked),
+			}
+			if src, ok := sources[item.Source]; ok && !placed[item.Source] {
+				li.Kind = src.Kind
+				li.Settings = src.Settings
+				li.Filters = src.layoutFilters()
+				placed[item.Source] = true
+			}
+			ls.Items = append(ls.Items, li)
+		}
+		layout.Scenes = append(layout.Scenes, ls)
+	}
+
+	plan, err := c.PlanLayout(layout)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.planGroups(plan, s); err != nil {
+		return nil, err
+	}
+
+	// Sources which are not placed in any scene cannot be created, but
+	// existing ones are updated.
+	for _, src := range s.Sources {
+		if placed[src.Name] || !exists[src.Name] {
+			continue
+		}
+		if err = c.planSourceSettings(plan, LayoutItem{Source: src.Name, Settings: src.Settings}); err != nil {
+			return nil, err
+		}
+		if err = c.planFilters(plan, LayoutItem{Source: src.Name, Filters: src.layoutFilters()}, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, src := range s.Sources {
+		if src.Audio != nil && (placed[src.Name] || exists[src.Name]) {
+			if err = c.planAudio(plan, src.Name, *src.Audio, exists[src.Name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, sc := range s.Scenes {
+		if o := sc.TransitionOverride; o != nil {
+			scene, name, duration := sc.Name, o.Name, o.Duration
+			plan.add("SetSceneTransitionOverride", func(c *Client) error {
+				_, err := c.SetSceneTransitionOverride(scene, name, &duration)
+				return err
+			}, "set transition override of scene %q to %q", scene, name)
+		}
+	}
+	if s.CurrentTransition != "" {
+		name, duration := s.CurrentTransition, s.TransitionDuration
+		plan.add("SetCurrentTransition", func(c *Client) error {
+			_, err := c.SetCurrentTransition(name)
+			return err
+		}, "set current transition to %q", name)
+		plan.add("SetTransitionDuration", func(c *Client) error {
+			_, err := c.SetTransitionDuration(duration)
+			return err
+		}, "set transition duration to %dms", duration)
+	}
+	if s.CurrentScene != "" {
+		name := s.CurrentScene
+		plan.add("SetCurrentScene", func(c *Client) error {
+			_, err := c.SetCurrentScene(name)
+			return err
+		}, "set current scene to %q", name)
+	}
+
+	if dryRun {
+		return plan, nil
+	}
+	return plan, plan.Apply(c)
+}
+
+// Function planGroups updates the items of existing groups to match the
+// snapshot. Items are matched to existing group items by their source, in
+// order.
+func (c *Client) planGroups(plan *Plan, s *CollectionSnapshot) error {
+	list, err := c.GetSceneList()
+	if err != nil {
+		return err
+	}
+	groups := make(map[string][]SceneItem)
+	var find func(items []SceneItem)
+	find = func(items []SceneItem) {
+		for _, v := range items {
+			if v.Type == "group" {
+				groups[v.Name] = v.GroupChildren
+				find(v.GroupChildren)
+			}
+		}
+	}
+	for _, sc := range list.Scenes {
+		find(sc.Sources)
+	}
+
+	done := make(map[string]bool)
+	var update func(group string, items []SnapshotItem) error
+	update = func(group string, items []SnapshotItem) error {
+		existing, ok := groups[group]
+		if !ok || done[group] {
+			return nil
+		}
+		done[group] = true
+		used := make(map[int]bool)
+		for _, item := range items {
+			for _, v := range existing {
+				if v.Name != item.Source || used[v.Id] {
+					continue
+				}
+				used[v.Id] = true
+				t := item.Transform
+				li := LayoutItem{
+					Source:    item.Source,
+					Transform: &t,
+					Visible:   ptr(item.Visible),
+					Locked:    ptr(item.Locked),
+				}
+				if err := c.planItemProperties(plan, group, v.Id, li); err != nil {
+					return err
+				}
+				break
+			}
+			if item.Group {
+				if err := update(item.Source, item.Children); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, sc := range s.Scenes {
+		for _, item := range sc.Items {
+			if item.Group {
+				if err := update(item.Source, item.Children); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (src SnapshotSource) layoutFilters() []LayoutFilter {
+	filters := []LayoutFilter{}
+	for _, f := range src.Filters {
+		filters = append(filters, LayoutFilter{
+			Name:     f.Name,
+			Kind:     f.Kind,
+			Settings: f.Settings,
+			Enabled:  ptr(f.Enabled),
+		})
+	}
+	return filters
+}
+
+// Function planAudio updates the audio settings of a source which differ
+// from the snapshot. Sources which do not exist yet have every setting
+// applied.
+func (c *Client) planAudio(plan *Plan, name string, a SnapshotAudio, exists bool) error {
+	cur := &AudioChannel{}
+	if exists {
+		var err error
+		if cur, err = c.loadAudioChannel(name, ""); err != nil {
+			return err
+		}
+	}
+
+	if !exists || cur.Volume != a.Volume {
+		plan.add("SetVolume", func(c *Client) error {
+			_, err := c.SetVolume(name, a.Volume, nil)
+			return err
+		}, "set volume of source %q to %.3f", name, a.Volume)
+	}
+	if !exists || cur.Muted != a.Muted {
+		plan.add("SetMute", func(c *Client) error {
+			_, err := c.SetMute(name, a.Muted)
+			return err
+		}, "set mute of source %q to %t", name, a.Muted)
+	}
+	if !exists || int(cur.SyncOffset) != a.SyncOffset {
+		plan.add("SetSyncOffset", func(c *Client) error {
+			_, err := c.SetSyncOffset(name, a.SyncOffset)
+			return err
+		}, "set sync offset of source %q to %dns", name, a.SyncOffset)
+	}
+	if a.MonitorType != "" && (!exists || cur.MonitorType != a.MonitorType) {
+		plan.add("SetAudioMonitorType", func(c *Client) error {
+			_, err := c.SetAudioMonitorType(name, a.MonitorType)
+			return err
+		}, "set monitor type of source %q to %s", name, a.MonitorType)
+	}
+	for i, active := range a.Tracks {
+		if exists && cur.Tracks[i] == active {
+			continue
+		}
+		track, active := i+1, active
+		plan.add("SetAudioTracks", func(c *Client) error {
+			_, err := c.SetAudioTracks(name, track, active)
+			return err
+		}, "set audio track %d of source %q to %t", track, name, active)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package go_obs_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function fakeCollection sets up a scene collection with a group holding
+// two sources, and returns the visibility of each item by ID.
+func fakeCollection(f *fakeOBS) map[int]bool {
+	visible := map[int]bool{1: true, 2: true, 3: true}
+	f.reply("GetCurrentSceneCollection", map[string]any{"sc-name": "Default"})
+	f.reply("GetTransitionList", map[string]any{"current-transition": "Fade", "transitions": []any{}})
+	f.reply("GetTransitionDuration", map[string]any{"transition-duration": 300})
+	f.reply("GetSourceTypesList", map[string]any{"types": []any{}})
+	f.reply("GetSourcesList", map[string]any{"sources": []any{
+		map[string]any{"name": "Camera", "typeId": "v4l2_input", "type": "input"},
+		map[string]any{"name": "Logo", "typeId": "image_source", "type": "input"},
+		map[string]any{"name": "Group", "typeId": "group", "type": "scene"},
+	}})
+	f.handle("GetSourceSettings", func(req map[string]any) (map[string]any, error) {
+		return map[string]any{"sourceName": req["sourceName"], "sourceSettings": map[string]any{}}, nil
+	})
+	f.reply("GetSourceFilters", map[string]any{"filters": []any{}})
+	f.reply("GetSceneList", map[string]any{
+		"current-scene": "Main",
+		"scenes": []any{
+			map[string]any{"name": "Main", "sources": []any{
+				map[string]any{"name": "Group", "id": 3, "type": "group", "groupChildren": []any{
+					map[string]any{"name": "Camera", "id": 1, "type": "v4l2_input", "parentGroupName": "Group"},
+					map[string]any{"name": "Logo", "id": 2, "type": "image_source", "parentGroupName": "Group"},
+				}},
+			}},
+			map[string]any{"name": "Other", "sources": []any{}},
+		},
+	})
+	f.reply("GetSceneTransitionOverride", map[string]any{"transitionName": "", "transitionDuration": -1})
+	f.handle("GetSceneItemProperties", func(req map[string]any) (map[string]any, error) {
+		id := int(req["item"].(map[string]any)["id"].(float64))
+		return map[string]any{
+			"itemId":   id,
+			"visible":  visible[id],
+			"position": map[string]any{"x": float64(id * 10), "y": 0, "alignment": 5},
+			"scale":    map[string]any{"x": 1, "y": 1, "filter": "OBS_SCALE_DISABLE"},
+			"bounds":   map[string]any{"type": "OBS_BOUNDS_NONE", "alignment": 0},
+		}, nil
+	})
+	return visible
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeCollection(f)
+	c := f.connect()
+
+	s, err := c.ExportCollection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Sources) != 3 {
+		t.Errorf("sources: %+v", s.Sources)
+	}
+	group := s.Scenes[0].Items[0]
+	if !group.Group || len(group.Children) != 2 || group.Children[1].Source != "Logo" {
+		t.Fatalf("group contents not exported: %+v", group)
+	}
+	if *group.Children[1].Transform.Position.X != 20 {
+		t.Errorf("group child transform: %+v", group.Children[1].Transform.Position)
+	}
+
+	buf := bytes.Buffer{}
+	if err = s.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	back, err := obs.ReadCollectionSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back.Created.Equal(s.Created) {
+		t.Errorf("created: %v, want %v", back.Created, s.Created)
+	}
+	back.Created = s.Created
+	if !reflect.DeepEqual(back.Scenes, s.Scenes) || back.CurrentScene != "Main" {
+		t.Errorf("round trip changed the snapshot:\n%+v\n%+v", back, s)
+	}
+}
+
+func TestSnapshotImport(t *testing.T) {
+	f := newFakeOBS(t)
+	visible := fakeCollection(f)
+	c := f.connect()
+
+	s, err := c.ExportCollection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := c.ImportCollection(s, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(plan.String(), "SetSceneItemProperties") {
+		t.Errorf("unchanged collection updates items:\n%s", plan)
+	}
+	if !strings.Contains(plan.String(), `SetCurrentScene: set current scene to "Main"`) {
+		t.Errorf("current scene not restored:\n%s", plan)
+	}
+
+	// Hiding an item in the group is reverted by importing the snapshot.
+	visible[2] = false
+	plan, err = c.ImportCollection(s, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plan.String(), `SetSceneItemProperties: update item "Logo" in scene "Group"`) {
+		t.Errorf("group item not updated:\n%s", plan)
+	}
+	if len(f.sent("SetSceneItemProperties")) != 0 {
+		t.Error("dry run applied the plan")
+	}
+}
+
+func TestSnapshotImportAudio(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeCollection(f)
+	f.reply("GetSourcesList", map[string]any{"sources": []any{
+		map[string]any{"name": "Mic", "typeId": "pulse_input_capture", "type": "input"},
+	}})
+	f.reply("GetVolume", map[string]any{"name": "Mic", "volume": 0.5, "muted": true})
+	f.reply("GetAudioMonitorType", map[string]any{"monitorType": "none"})
+	f.reply("GetSyncOffset", map[string]any{"name": "Mic", "offset": 0})
+	f.reply("GetAudioTracks", map[string]any{"track1": true, "track2": true})
+	c := f.connect()
+
+	s := &obs.CollectionSnapshot{
+		Version: obs.CollectionSnapshotVersion,
+		Sources: []obs.SnapshotSource{{
+			Name: "Mic",
+			Kind: "pulse_input_capture",
+			Audio: &obs.SnapshotAudio{
+				Volume:      0.5,
+				MonitorType: "none",
+				Tracks:      [6]bool{true},
+			},
+		}},
+	}
+	plan, err := c.ImportCollection(s, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the mute state and the second track differ.
+	var types []string
+	for _, step := range plan.Steps {
+		types = append(types, step.RequestType)
+	}
+	if want := []string{"SetMute", "SetAudioTracks"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("got plan:\n%s", plan)
+	}
+	if !strings.Contains(plan.String(), `set audio track 2 of source "Mic" to false`) {
+		t.Errorf("got plan:\n%s", plan)
+	}
+}
@@ -0,0 +1,406 @@
+package go_obs
+
+import (
+	"errors"
+	"reflect"
+)
+
+// SourceSettings is implemented by the typed settings of each known source
+// kind. Settings which are not described by the typed struct are kept in
+// its Extra map, so that they survive being read and written back.
+type SourceSettings interface {
+	// The source kind (eg. `ffmpeg_source`) these settings belong to.
+	SourceKind() string
+}
+
+// Function CreateTypedSource creates a new source of the kind described by
+// the given settings, and adds it to the given scene.
+func (c *Client) CreateTypedSource(sourceName string, sceneName string, settings SourceSettings, setVisible *bool) (*CreateSourceResponse, error) {
+	if settings == nil {
+		return nil, errors.New("no source settings")
+	}
+	return c.CreateSource(sourceName, settings.SourceKind(), sceneName, settings, setVisible)
+}
+
+// Function GetTypedSourceSettings reads the settings of the given source
+// into settings, which should be a pointer to one of the typed settings
+// structs. It fails if the source is of a different kind.
+func (c *Client) GetTypedSourceSettings(sourceName string, settings SourceSettings) error {
+	res, err := c.GetSourceSettings(sourceName, "")
+	if err != nil {
+		return err
+	}
+	if res.SourceType != settings.SourceKind() {
+		// Older versions of a kind are read into the same settings.
+		s := sourceSettingsFor(res.SourceType)
+		if s == nil || reflect.TypeOf(s) != reflect.TypeOf(settings) {
+			return errors.New("source " + sourceName + " is of kind " + res.SourceType + ", not " + settings.SourceKind())
+		}
+	}
+	return remarshal(res.SourceSettings, settings)
+}
+
+// Function SetTypedSourceSettings updates the settings of the given source.
+// Settings which are left unset are not changed.
+func (c *Client) SetTypedSourceSettings(sourceName string, settings SourceSettings) error {
+	_, err := c.SetSourceSettings(sourceName, "", settings)
+	return err
+}
+
+// Font settings used by text sources.
+type SourceFont struct {
+	// Font face.
+	Face *string `json:"face,omitempty"`
+	// Font text styling flag. `Bold=1, Italic=2, Bold Italic=3, Underline=5,
+	// Strikeout=8`
+	Flags *int `json:"flags,omitempty"`
+	// Font text size.
+	Size *int `json:"size,omitempty"`
+	// Font style.
+	Style *string `json:"style,omitempty"`
+}
+
+// Settings of a media source (`ffmpeg_source`).
+type FFmpegSourceSettings struct {
+	// Whether the source plays a local file or a network input.
+	IsLocalFile *bool `json:"is_local_file,omitempty"`
+	// Path of the local file to play.
+	LocalFile *string `json:"local_file,omitempty"`
+	// URL of the network input to play.
+	Input *string `json:"input,omitempty"`
+	// Format of the network input, if it cannot be detected.
+	InputFormat *string `json:"input_format,omitempty"`
+	// Whether the media loops.
+	Looping *bool `json:"looping,omitempty"`
+	// Whether playback restarts when the source becomes active.
+	RestartOnActivate *bool `json:"restart_on_activate,omitempty"`
+	// Whether the source is cleared when playback ends.
+	ClearOnMediaEnd *bool `json:"clear_on_media_end,omitempty"`
+	// Whether the file is closed when the source is inactive.
+	CloseWhenInactive *bool `json:"close_when_inactive,omitempty"`
+	// Whether hardware decoding is used when available.
+	HwDecode *bool `json:"hw_decode,omitempty"`
+	// Network buffering (in megabytes).
+	BufferingMb *int `json:"buffering_mb,omitempty"`
+	// Playback speed (in percent).
+	SpeedPercent *int `json:"speed_percent,omitempty"`
+	// Delay before reconnecting to a network input (in seconds).
+	ReconnectDelaySec *int `json:"reconnect_delay_sec,omitempty"`
+	// Whether the input is seekable.
+	Seekable *bool `json:"seekable,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s FFmpegSourceSettings) SourceKind() string {
+	return "ffmpeg_source"
+}
+
+func (s FFmpegSourceSettings) MarshalJSON() ([]byte, error) {
+	type plain FFmpegSourceSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *FFmpegSourceSettings) UnmarshalJSON(data []byte) error {
+	type plain FFmpegSourceSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// An item of a VLC source playlist.
+type VLCPlaylistItem struct {
+	// Path or URL of the item.
+	Value string `json:"value"`
+	// Whether the item is hidden.
+	Hidden bool `json:"hidden"`
+	// Whether the item is selected.
+	Selected bool `json:"selected"`
+}
+
+// Settings of a VLC video source (`vlc_source`).
+type VLCSourceSettings struct {
+	// The playlist of files or URLs to play.
+	Playlist []VLCPlaylistItem `json:"playlist,omitempty"`
+	// Whether the playlist loops.
+	Loop *bool `json:"loop,omitempty"`
+	// Whether the playlist is shuffled.
+	Shuffle *bool `json:"shuffle,omitempty"`
+	// Visibility behavior. Can be "stop_restart", "pause_unpause" or
+	// "always_play".
+	PlaybackBehavior *string `json:"playback_behavior,omitempty"`
+	// Network caching (in milliseconds).
+	NetworkCaching *int `json:"network_caching,omitempty"`
+	// Audio track.
+	Track *int `json:"track,omitempty"`
+	// Whether subtitles are shown.
+	SubtitleEnable *bool `json:"subtitle_enable,omitempty"`
+	// Subtitle track.
+	Subtitle *int `json:"subtitle,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s VLCSourceSettings) SourceKind() string {
+	return "vlc_source"
+}
+
+func (s VLCSourceSettings) MarshalJSON() ([]byte, error) {
+	type plain VLCSourceSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *VLCSourceSettings) UnmarshalJSON(data []byte) error {
+	type plain VLCSourceSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a browser source (`browser_source`).
+type BrowserSourceSettings struct {
+	// Whether the source displays a local file rather than a URL.
+	IsLocalFile *bool `json:"is_local_file,omitempty"`
+	// Path of the local file to display.
+	LocalFile *string `json:"local_file,omitempty"`
+	// URL to display.
+	Url *string `json:"url,omitempty"`
+	// Width of the page.
+	Width *int `json:"width,omitempty"`
+	// Height of the page.
+	Height *int `json:"height,omitempty"`
+	// Whether a custom framerate is used.
+	FpsCustom *bool `json:"fps_custom,omitempty"`
+	// Custom framerate.
+	Fps *int `json:"fps,omitempty"`
+	// Custom CSS applied to the page.
+	Css *string `json:"css,omitempty"`
+	// Whether the page is unloaded when the source is not visible.
+	Shutdown *bool `json:"shutdown,omitempty"`
+	// Whether the page is refreshed when the scene becomes active.
+	RestartWhenActive *bool `json:"restart_when_active,omitempty"`
+	// Whether audio is controlled by OBS.
+	RerouteAudio *bool `json:"reroute_audio,omitempty"`
+	// Level of control the page has over OBS.
+	WebpageControlLevel *int `json:"webpage_control_level,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s BrowserSourceSettings) SourceKind() string {
+	return "browser_source"
+}
+
+func (s BrowserSourceSettings) MarshalJSON() ([]byte, error) {
+	type plain BrowserSourceSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *BrowserSourceSettings) UnmarshalJSON(data []byte) error {
+	type plain BrowserSourceSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a FreeType 2 text source (`text_ft2_source_v2`).
+type TextFreetype2SourceSettings struct {
+	// Text content to be displayed.
+	Text *string `json:"text,omitempty"`
+	// Whether the text is read from a file.
+	FromFile *bool `json:"from_file,omitempty"`
+	// File path of the text to read.
+	TextFile *string `json:"text_file,omitempty"`
+	// Whether only the last lines of the file are shown.
+	LogMode *bool `json:"log_mode,omitempty"`
+	// Number of lines shown in log mode.
+	LogLines *int `json:"log_lines,omitempty"`
+	// Holds data for the font.
+	Font *SourceFont `json:"font,omitempty"`
+	// Gradient top color.
+	Color1 *int `json:"color1,omitempty"`
+	// Gradient bottom color.
+	Color2 *int `json:"color2,omitempty"`
+	// Whether the text has an outline.
+	Outline *bool `json:"outline,omitempty"`
+	// Whether the text has a drop shadow.
+	DropShadow *bool `json:"drop_shadow,omitempty"`
+	// Custom width (0 to disable).
+	CustomWidth *int `json:"custom_width,omitempty"`
+	// Whether the text wraps at the custom width.
+	WordWrap *bool `json:"word_wrap,omitempty"`
+	// Whether the text is antialiased.
+	Antialiasing *bool `json:"antialiasing,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s TextFreetype2SourceSettings) SourceKind() string {
+	return "text_ft2_source_v2"
+}
+
+func (s TextFreetype2SourceSettings) MarshalJSON() ([]byte, error) {
+	type plain TextFreetype2SourceSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *TextFreetype2SourceSettings) UnmarshalJSON(data []byte) error {
+	type plain TextFreetype2SourceSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of an image source (`image_source`).
+type ImageSourceSettings struct {
+	// Path of the image file.
+	File *string `json:"file,omitempty"`
+	// Whether the image is unloaded when the source is not showing.
+	Unload *bool `json:"unload,omitempty"`
+	// Whether alpha is applied in linear space.
+	LinearAlpha *bool `json:"linear_alpha,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s ImageSourceSettings) SourceKind() string {
+	return "image_source"
+}
+
+func (s ImageSourceSettings) MarshalJSON() ([]byte, error) {
+	type plain ImageSourceSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *ImageSourceSettings) UnmarshalJSON(data []byte) error {
+	type plain ImageSourceSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a color source (`color_source_v3`).
+type ColorSourceSettings struct {
+	// Color, in ABGR byte order (eg. 0xFF0000FF is opaque red).
+	Color *int `json:"color,omitempty"`
+	// Width of the source.
+	Width *int `json:"width,omitempty"`
+	// Height of the source.
+	Height *int `json:"height,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s ColorSourceSettings) SourceKind() string {
+	return "color_source_v3"
+}
+
+func (s ColorSourceSettings) MarshalJSON() ([]byte, error) {
+	type plain ColorSourceSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *ColorSourceSettings) UnmarshalJSON(data []byte) error {
+	type plain ColorSourceSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of a Windows window capture source (`window_capture`).
+type WindowCaptureSettings struct {
+	// The window to capture, as "title:class:executable".
+	Window *string `json:"window,omitempty"`
+	// Capture method.
+	Method *int `json:"method,omitempty"`
+	// Window match priority.
+	Priority *int `json:"priority,omitempty"`
+	// Whether the cursor is captured.
+	Cursor *bool `json:"cursor,omitempty"`
+	// Whether multi-adapter compatibility is enabled.
+	Compatibility *bool `json:"compatibility,omitempty"`
+	// Whether only the client area of the window is captured.
+	ClientArea *bool `json:"client_area,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s WindowCaptureSettings) SourceKind() string {
+	return "window_capture"
+}
+
+func (s WindowCaptureSettings) MarshalJSON() ([]byte, error) {
+	type plain WindowCaptureSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *WindowCaptureSettings) UnmarshalJSON(data []byte) error {
+	type plain WindowCaptureSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Settings of an X11 window capture source (`xcomposite_input`).
+type XCompositeCaptureSettings struct {
+	// The window to capture.
+	CaptureWindow *string `json:"capture_window,omitempty"`
+	// Number of pixels cropped off the top of the window.
+	CutTop *int `json:"cut_top,omitempty"`
+	// Number of pixels cropped off the left of the window.
+	CutLeft *int `json:"cut_left,omitempty"`
+	// Number of pixels cropped off the right of the window.
+	CutRight *int `json:"cut_right,omitempty"`
+	// Number of pixels cropped off the bottom of the window.
+	CutBot *int `json:"cut_bot,omitempty"`
+	// Whether the red and blue channels are swapped.
+	SwapRedBlue *bool `json:"swap_redblue,omitempty"`
+	// Whether the capture size is locked.
+	LockX *bool `json:"lock_x,omitempty"`
+	// Whether the cursor is captured.
+	ShowCursor *bool `json:"show_cursor,omitempty"`
+	// Whether the window border is included.
+	IncludeBorder *bool `json:"include_border,omitempty"`
+	// Whether the alpha channel is ignored.
+	ExcludeAlpha *bool `json:"exclude_alpha,omitempty"`
+	// Unknown settings.
+	Extra map[string]any `json:"-"`
+}
+
+func (s XCompositeCaptureSettings) SourceKind() string {
+	return "xcomposite_input"
+}
+
+func (s XCompositeCaptureSettings) MarshalJSON() ([]byte, error) {
+	type plain XCompositeCaptureSettings
+	return marshalSettings(plain(s), s.Extra)
+}
+
+func (s *XCompositeCaptureSettings) UnmarshalJSON(data []byte) error {
+	type plain XCompositeCaptureSettings
+	return unmarshalSettings(data, (*plain)(s), &s.Extra)
+}
+
+// Function sourceSettingsFor returns new, empty typed settings for the given
+// source kind, or nil if the kind has no typed settings.
+func sourceSettingsFor(kind string) SourceSettings {
+	switch kind {
+	case "ffmpeg_source":
+		return &FFmpegSourceSettings{}
+	case "vlc_source":
+		return &VLCSourceSettings{}
+	case "browser_source":
+		return &BrowserSourceSettings{}
+	case "text_ft2_source", "text_ft2_source_v2":
+		return &TextFreetype2SourceSettings{}
+	case "image_source":
+		return &ImageSourceSettings{}
+	case "color_source", "color_source_v2", "color_source_v3":
+		return &ColorSourceSettings{}
+	case "window_capture":
+		return &WindowCaptureSettings{}
+	case "xcomposite_input":
+		return &XCompositeCaptureSettings{}
+	}
+	return nil
+}
+
+// Function DecodeSourceSettings decodes untyped source settings, such as
+// those of GetSourceSettingsResponse, into the typed settings for the given
+// source kind. Unknown kinds return an error.
+func DecodeSourceSettings(kind string, settings any) (SourceSettings, error) {
+	s := sourceSettingsFor(kind)
+	if s == nil {
+		return nil, errors.New("no typed settings for source kind: " + kind)
+	}
+	if err := remarshal(settings, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,81 @@
+package go_obs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestSourceSettingsRoundTrip(t *testing.T) {
+	in := `{"local_file":"/tmp/a.mp4","looping":true,"some_new_key":[1,2]}`
+	s, err := obs.DecodeSourceSettings("ffmpeg_source", json.RawMessage(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ff := s.(*obs.FFmpegSourceSettings)
+	if ff.LocalFile == nil || *ff.LocalFile != "/tmp/a.mp4" || ff.Looping == nil || !*ff.Looping {
+		t.Errorf("bad decode: %+v", ff)
+	}
+	if _, ok := ff.Extra["some_new_key"]; !ok {
+		t.Errorf("unknown key was dropped: %+v", ff.Extra)
+	}
+
+	out, err := json.Marshal(ff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]any{}
+	if err = json.Unmarshal(out, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 3 || m["some_new_key"] == nil {
+		t.Errorf("bad encode: %s", out)
+	}
+}
+
+func TestSourceSettingsEmptyString(t *testing.T) {
+	empty := ""
+	out, err := json.Marshal(obs.TextFreetype2SourceSettings{Text: &empty})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"text":""}` {
+		t.Errorf("empty text not sent: %s", out)
+	}
+	out, err = json.Marshal(obs.TextFreetype2SourceSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{}` {
+		t.Errorf("unset text sent: %s", out)
+	}
+}
+
+func TestGetTypedSourceSettingsKind(t *testing.T) {
+	f := newFakeOBS(t)
+	f.handle("GetSourceSettings", func(req map[string]any) (map[string]any, error) {
+		kinds := map[string]string{"Logo": "image_source", "Background": "color_source_v2"}
+		return map[string]any{
+			"sourceName":     req["sourceName"],
+			"sourceType":     kinds[req["sourceName"].(string)],
+			"sourceSettings": map[string]any{"file": "/tmp/logo.png", "color": 4278190080.0},
+		}, nil
+	})
+	c := f.connect()
+
+	image := obs.ImageSourceSettings{}
+	if err := c.GetTypedSourceSettings("Logo", &image); err != nil {
+		t.Fatal(err)
+	}
+	if image.File == nil || *image.File != "/tmp/logo.png" {
+		t.Errorf("image: %+v", image)
+	}
+	if err := c.GetTypedSourceSettings("Logo", &obs.FFmpegSourceSettings{}); err == nil {
+		t.Error("image source read as a media source")
+	}
+	// An older version of the kind has the same settings.
+	if err := c.GetTypedSourceSettings("Background", &obs.ColorSourceSettings{}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,740 @@
+package go_obs
+
+import "sync"
+
+// StateCache mirrors the scenes, scene items, sources, filters and
+// transitions of an OBS instance. It loads the complete state when created
+// and keeps itself up to date from events, so that the state can be read
+// without issuing requests.
+type StateCache struct {
+	c          *Client
+	mx         sync.RWMutex
+	state      StateSnapshot
+	audioKinds map[string]bool
+	loading    bool
+	pending    []any
+	onChange   map[int]func(StateChange)
+	nextId     int
+	remove     []func()
+}
+
+// A copy of the cached OBS state at some point in time.
+type StateSnapshot struct {
+	// Name of the current (program) scene.
+	CurrentScene string
+	// Name of the preview scene, if studio mode is enabled.
+	PreviewScene string
+	// Name of the current transition.
+	CurrentTransition string
+	// Duration of the current transition (in milliseconds).
+	TransitionDuration int
+	// Names of all transitions.
+	Transitions []string
+	// Ordered list of scenes. Groups are included after the scenes, with
+	// their Group field set.
+	Scenes []StateScene
+	// All sources, by name.
+	Sources map[string]StateSource
+}
+
+// Cached state of a scene or group.
+type StateScene struct {
+	// Name of the scene.
+	Name string
+	// Whether this is a group rather than a scene.
+	Group bool
+	// Ordered list of the scene's items, from top to bottom.
+	Items []StateSceneItem
+}
+
+// Cached state of a scene item.
+type StateSceneItem struct {
+	// Scene item ID.
+	Id int
+	// Name of the item's source.
+	SourceName string
+	// Source type. Value is one of the following: "input", "filter",
+	// "transition", "scene", "group" or "unknown".
+	SourceType string
+	// Whether the item is visible.
+	Visible bool
+	// Whether the item is locked.
+	Locked bool
+	// Name of the item's parent group, if it belongs to one.
+	ParentGroupName string
+	// The item's transform, if it has been fetched or changed.
+	Transform *SceneItemTransform
+}
+
+// Cached state of a source.
+type StateSource struct {
+	// Source name.
+	Name string
+	// Source kind (eg. `ffmpeg_source`).
+	Kind string
+	// Source type. Value is one of the following: "input", "filter",
+	// "transition", "scene" or "unknown".
+	Type string
+	// Whether the source has audio.
+	Audio bool
+	// Whether the source is muted.
+	Muted bool
+	// Volume of the source as a multiplier.
+	Volume float64
+	// Volume of the source in decibels.
+	VolumeDb float64
+	// Ordered list of the source's filters.
+	Filters []StateFilter
+}
+
+// Cached state of a filter.
+type StateFilter struct {
+	// Filter name.
+	Name string
+	// Filter type.
+	Type string
+	// Whether the filter is enabled.
+	Enabled bool
+	// Filter settings. This is shared between snapshots and must not be
+	// modified.
+	Settings interface{}
+}
+
+// A change to the cached state.
+type StateChange struct {
+	// The update type of the event which caused the change, or an empty
+	// string if the state was reloaded.
+	UpdateType string
+	// The event which caused the change. This is nil if the state was
+	// reloaded, or if the change completes an earlier event with data
+	// fetched from OBS.
+	Event any
+}
+
+var stateEvents = []string{
+	"SwitchScenes",
+	"PreviewSceneChanged",
+	"ScenesChanged",
+	"SceneCollectionChanged",
+	"SceneItemAdded",
+	"SceneItemRemoved",
+	"SceneItemVisibilityChanged",
+	"SceneItemLockChanged",
+	"SceneItemTransformChanged",
+	"SourceOrderChanged",
+	"SourceCreated",
+	"SourceDestroyed",
+	"SourceRenamed",
+	"SourceVolumeChanged",
+	"SourceMuteStateChanged",
+	"SourceFilterAdded",
+	"SourceFilterRemoved",
+	"SourceFilterVisibilityChanged",
+	"SourceFiltersReordered",
+	"SwitchTransition",
+	"TransitionListChanged",
+	"TransitionDurationChanged",
+	"StudioModeSwitched",
+}
+
+// Function NewStateCache creates a state cache for the given client, which
+// must be connected, and loads the current state.
+func NewStateCache(c *Client) (*StateCache, error) {
+	s := &StateCache{
+		c:        c,
+		onChange: make(map[int]func(StateChange)),
+	}
+	for _, e := range stateEvents {
+		s.remove = append(s.remove, c.addListener(e, s.handle))
+	}
+	if err := s.Reload(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Function Close stops the state cache from following events.
+func (s *StateCache) Close() {
+	s.mx.Lock()
+	remove := s.remove
+	s.remove = nil
+	s.mx.Unlock()
+	for _, r := range remove {
+		r()
+	}
+}
+
+// Function OnChange registers fn to be called after every change to the
+// state. The callback runs on the client's read loop, so it must not block
+// on requests. The returned function unregisters the callback.
+func (s *StateCache) OnChange(fn func(StateChange)) func() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	id := s.nextId
+	s.nextId++
+	s.onChange[id] = fn
+	return func() {
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		delete(s.onChange, id)
+	}
+}
+
+// Function Snapshot returns a copy of the current state.
+func (s *StateCache) Snapshot() StateSnapshot {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.state.clone()
+}
+
+// Function CurrentScene returns the name of the current scene.
+func (s *StateCache) CurrentScene() string {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	return s.state.CurrentScene
+}
+
+// Function Scene returns a copy of the scene or group with the given name.
+func (s *StateCache) Scene(name string) (StateScene, bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	if sc := s.state.scene(name); sc != nil {
+		return sc.clone(), true
+	}
+	return StateScene{}, false
+}
+
+// Function Source returns a copy of the source with the given name.
+func (s *StateCache) Source(name string) (StateSource, bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	src, ok := s.state.Sources[name]
+	if !ok {
+		return StateSource{}, false
+	}
+	return src.clone(), true
+}
+
+// Function Reload discards the cached state and loads it again. Events
+// which arrive while loading are applied afterwards.
+func (s *StateCache) Reload() error {
+	s.mx.Lock()
+	s.loading = true
+	s.pending = nil
+	s.mx.Unlock()
+
+	state, audioKinds, err := s.load()
+
+	s.mx.Lock()
+	pending := s.pending
+	s.loading = false
+	s.pending = nil
+	var refresh []func()
+	if err == nil {
+		s.state = state
+		s.audioKinds = audioKinds
+		for _, e := range pending {
+			if fn := s.apply(e); fn != nil {
+				refresh = append(refresh, fn)
+			}
+		}
+	}
+	s.mx.Unlock()
+	if err != nil {
+		return err
+	}
+	s.notify(StateChange{})
+	for _, fn := range refresh {
+		go fn()
+	}
+	return nil
+}
+
+func (s *StateCache) load() (StateSnapshot, map[string]bool, error) {
+	c := s.c
+	state := StateSnapshot{Sources: make(map[string]StateSource)}
+
+	sources, audioKinds, err := c.getSourcesWithAudio()
+	if err != nil {
+		return state, nil, err
+	}
+	for _, v := range sources.Sources {
+		src := StateSource{
+			Name:  v.Name,
+			Kind:  v.TypeId,
+			Type:  v.Type,
+			Audio: audioKinds[v.TypeId],
+		}
+		if src.Audio {
+			vol, err := c.GetVolume(v.Name, nil)
+			if err != nil {
+				return state, nil, err
+			}
+			src.Muted = vol.Muted
+			src.Volume = vol.Volume
+			src.VolumeDb = mulToDb(vol.Volume)
+		}
+		filters, err := c.GetSourceFilters(v.Name)
+		if err != nil {
+			return state, nil, err
+		}
+		for _, f := range filters.Filters {
+			src.Filters = append(src.Filters, StateFilter{
+				Name:     f.Name,
+				Type:     f.Type,
+				Enabled:  f.Enabled,
+				Settings: f.Settings,
+			})
+		}
+		state.Sources[v.Name] = src
+	}
+
+	scenes, err := c.GetSceneList()
+	if err != nil {
+		return state, nil, err
+	}
+	state.CurrentScene = scenes.CurrentScene
+	state.setScenes(scenes.Scenes)
+
+	studio, err := c.GetStudioModeStatus()
+	if err != nil {
+		return state, nil, err
+	}
+	if studio.StudioMode {
+		preview, err := c.GetPreviewScene()
+		if err != nil {
+			return state, nil, err
+		}
+		state.PreviewScene = preview.Name
+	}
+
+	transitions, err := c.GetTransitionList()
+	if err != nil {
+		return state, nil, err
+	}
+	state.CurrentTransition = transitions.CurrentTransition
+	for _, t := range transitions.Transitions {
+		state.Transitions = append(state.Transitions, t.Name)
+	}
+	duration, err := c.GetTransitionDuration()
+	if err != nil {
+		return state, nil, err
+	}
+	state.TransitionDuration = duration.TransitionDuration
+
+	return state, audioKinds, nil
+}
+
+// Function handle applies an event to the state and notifies listeners.
+func (s *StateCache) handle(event any) {
+	s.mx.Lock()
+	if s.loading {
+		s.pending = append(s.pending, event)
+		s.mx.Unlock()
+		return
+	}
+	refresh := s.apply(event)
+	s.mx.Unlock()
+
+	s.notify(StateChange{
+		UpdateType: updateType(event),
+		Event:      event,
+	})
+	if refresh != nil {
+		go refresh()
+	}
+}
+
+func (s *StateCache) notify(change StateChange) {
+	s.mx.RLock()
+	fns := make([]func(StateChange), 0, len(s.onChange))
+	for _, fn := range s.onChange {
+		fns = append(fns, fn)
+	}
+	s.mx.RUnlock()
+	for _, fn := range fns {
+		fn(change)
+	}
+}
+
+// Function apply applies an event to the state. The lock must be held. If
+// the event does not carry enough information, apply returns a function
+// which fetches the rest; it must be called without holding the lock.
+func (s *StateCache) apply(event any) func() {
+	st := &s.state
+	switch e := event.(type) {
+	case *SwitchScenesEvent:
+		st.CurrentScene = e.SceneName
+	case *PreviewSceneChangedEvent:
+		st.PreviewScene = e.SceneName
+	case *StudioModeSwitchedEvent:
+		if !e.NewState {
+			st.PreviewScene = ""
+		}
+	case *ScenesChangedEvent:
+		st.setScenes(e.Scenes)
+	case *SceneCollectionChangedEvent:
+		return func() { s.Reload() }
+	case *SceneItemAddedEvent:
+		sc := st.scene(e.SceneName)
+		if sc == nil || sc.item(e.ItemId) != nil {
+			return nil
+		}
+		// New items are added to the top of the scene.
+		item := StateSceneItem{
+			Id:         e.ItemId,
+			SourceName: e.ItemName,
+			SourceType: st.Sources[e.ItemName].Type,
+			Visible:    true,
+		}
+		if sc.Group {
+			item.ParentGroupName = sc.Name
+		}
+		sc.Items = append([]StateSceneItem{item}, sc.Items...)
+		return func() { s.fetchItem(e.SceneName, e.ItemId) }
+	case *SceneItemRemovedEvent:
+		if sc := st.scene(e.SceneName); sc != nil {
+			for i, v := range sc.Items {
+				if v.Id == e.ItemId {
+					sc.Items = append(sc.Items[:i:i], sc.Items[i+1:]...)
+					break
+				}
+			}
+		}
+	case *SceneItemVisibilityChangedEvent:
+		if item := st.item(e.SceneName, e.ItemId); item != nil {
+			item.Visible = e.ItemVisible
+			if item.Transform != nil {
+				item.Transform.Visible = e.ItemVisible
+			}
+		}
+	case *SceneItemLockChangedEvent:
+		if item := st.item(e.SceneName, e.ItemId); item != nil {
+			item.Locked = e.ItemLocked
+			if item.Transform != nil {
+				item.Transform.Locked = e.ItemLocked
+			}
+		}
+	case *SceneItemTransformChangedEvent:
+		if item := st.item(e.SceneName, e.ItemId); item != nil {
+			t := e.Transform
+			item.Transform = &t
+			item.Visible = t.Visible
+			item.Locked = t.Locked
+		}
+	case *SourceOrderChangedEvent:
+		if sc := st.scene(e.SceneName); sc != nil {
+			items := make([]StateSceneItem, 0, len(sc.Items))
+			for _, v := range e.SceneItems {
+				if item := sc.item(v.ItemId); item != nil {
+					items = append(items, *item)
+				}
+			}
+			sc.Items = items
+		}
+	case *SourceCreatedEvent:
+		if _, ok := st.Sources[e.SourceName]; ok {
+			return nil
+		}
+		src := StateSource{
+			Name:  e.SourceName,
+			Kind:  e.SourceKind,
+			Type:  e.SourceType,
+			Audio: s.audioKinds[e.SourceKind],
+		}
+		if src.Audio {
+			src.Volume = 1
+		}
+		st.Sources[e.SourceName] = src
+		if e.SourceType == "scene" && st.scene(e.SourceName) == nil {
+			st.Scenes = append(st.Scenes, StateScene{Name: e.SourceName})
+		}
+	case *SourceDestroyedEvent:
+		delete(st.Sources, e.SourceName)
+		for i, v := range st.Scenes {
+			if v.Name == e.SourceName {
+				st.Scenes = append(st.Scenes[:i:i], st.Scenes[i+1:]...)
+				break
+			}
+		}
+	case *SourceRenamedEvent:
+		st.rename(e.PreviousName, e.NewName)
+	case *SourceVolumeChangedEvent:
+		if src, ok := st.Sources[e.SourceName]; ok {
+			src.Volume = float64(e.Volume)
+			src.VolumeDb = float64(e.VolumeDb)
+			st.Sources[e.SourceName] = src
+		}
+	case *SourceMuteStateChangedEvent:
+		if src, ok := st.Sources[e.SourceName]; ok {
+			src.Muted = e.Muted
+			st.Sources[e.SourceName] = src
+		}
+	case *SourceFilterAddedEvent:
+		if src, ok := st.Sources[e.SourceName]; ok {
+			if src.filter(e.FilterName) != nil {
+				return nil
+			}
+			src.Filters = append(src.Filters[:len(src.Filters):len(src.Filters)], StateFilter{
+				Name:     e.FilterName,
+				Type:     e.FilterType,
+				Enabled:  true,
+				Settings: e.FilterSettings,
+			})
+			st.Sources[e.SourceName] = src
+		}
+	case *SourceFilterRemovedEvent:
+		if src, ok := st.Sources[e.SourceName]; ok {
+			for i, f := range src.Filters {
+				if f.Name == e.FilterName {
+					src.Filters = append(src.Filters[:i:i], src.Filters[i+1:]...)
+					break
+				}
+			}
+			st.Sources[e.SourceName] = src
+		}
+	case *SourceFilterVisibilityChangedEvent:
+		if src, ok := st.Sources[e.SourceName]; ok {
+			src.Filters = append([]StateFilter(nil), src.Filters...)
+			if f := src.filter(e.FilterName); f != nil {
+				f.Enabled = e.FilterEnabled
+			}
+			st.Sources[e.SourceName] = src
+		}
+	case *SourceFiltersReorderedEvent:
+		if src, ok := st.Sources[e.SourceName]; ok {
+			filters := make([]StateFilter, 0, len(e.Filters))
+			for _, v := range e.Filters {
+				f := StateFilter{Name: v.Name, Type: v.Type, Enabled: v.Enabled}
+				if old := src.filter(v.Name); old != nil {
+					f.Settings = old.Settings
+				}
+				filters = append(filters, f)
+			}
+			src.Filters = filters
+			st.Sources[e.SourceName] = src
+		}
+	case *SwitchTransitionEvent:
+		st.CurrentTransition = e.TransitionName
+	case *TransitionListChangedEvent:
+		st.Transitions = st.Transitions[:0:0]
+		for _, t := range e.Transitions {
+			st.Transitions = append(st.Transitions, t.Name)
+		}
+	case *TransitionDurationChangedEvent:
+		st.TransitionDuration = e.NewDuration
+	}
+	return nil
+}
+
+// Function fetchItem fills in the visibility, lock state and transform of a
+// newly added scene item.
+func (s *StateCache) fetchItem(scene string, id int) {
+	res, err := s.c.GetSceneItemProperties(scene, ItemById(id))
+	if err != nil {
+		return
+	}
+
+	s.mx.Lock()
+	item := s.state.item(scene, id)
+	if item != nil {
+		t := transformFromProperties(res)
+		item.Transform = &t
+		item.Visible = res.Visible
+		item.Locked = res.Locked
+	}
+	s.mx.Unlock()
+	if item != nil {
+		s.notify(StateChange{UpdateType: "SceneItemAdded"})
+	}
+}
+
+// Function transformFromProperties converts a GetSceneItemProperties
+// response into a scene item transform.
+func transformFromProperties(res *GetSceneItemPropertiesResponse) SceneItemTransform {
+	t := SceneItemTransform{
+		Rotation:        res.Rotation,
+		Visible:         res.Visible,
+		Locked:          res.Locked,
+		SourceWidth:     res.SourceWidth,
+		SourceHeight:    res.SourceHeight,
+		Width:           res.Width,
+		Height:          res.Height,
+		ParentGroupName: res.ParentGroupName,
+		GroupChildren:   res.GroupChildren,
+	}
+	t.Position.X = res.Position.X
+	t.Position.Y = res.Position.Y
+	t.Position.Alignment = res.Position.Alignment
+	t.Scale.X = res.Scale.X
+	t.Scale.Y = res.Scale.Y
+	t.Scale.Filter = res.Scale.Filter
+	t.Crop.Top = res.Crop.Top
+	t.Crop.Right = res.Crop.Right
+	t.Crop.Bottom = res.Crop.Bottom
+	t.Crop.Left = res.Crop.Left
+	t.Bounds.Type = res.Bounds.Type
+	t.Bounds.Alignment = res.Bounds.Alignment
+	t.Bounds.X = res.Bounds.X
+	t.Bounds.Y = res.Bounds.Y
+	return t
+}
+
+// Function setScenes replaces the scenes and groups with those in the given
+// scene list, keeping known transforms.
+func (st *StateSnapshot) setScenes(scenes []Scene) {
+	old := make(map[string]map[int]*SceneItemTransform)
+	for _, sc := range st.Scenes {
+		m := make(map[int]*SceneItemTransform)
+		for _, item := range sc.Items {
+			m[item.Id] = item.Transform
+		}
+		old[sc.Name] = m
+	}
+
+	st.Scenes = st.Scenes[:0:0]
+	groups := []StateScene{}
+	var addItems func(sc *StateScene, items []SceneItem)
+	addItems = func(sc *StateScene, items []SceneItem) {
+		for _, v := range items {
+			sc.Items = append(sc.Items, StateSceneItem{
+				Id:              v.Id,
+				SourceName:      v.Name,
+				SourceType:      v.Type,
+				Visible:         v.Render,
+				Locked:          v.Locked,
+				ParentGroupName: v.ParentGroupName,
+				Transform:       old[sc.Name][v.Id],
+			})
+			if v.Type == "group" {
+				g := StateScene{Name: v.Name, Group: true}
+				addItems(&g, v.GroupChildren)
+				groups = append(groups, g)
+			}
+		}
+	}
+	for _, v := range scenes {
+		sc := StateScene{Name: v.Name}
+		addItems(&sc, v.Sources)
+		st.Scenes = append(st.Scenes, sc)
+	}
+	st.Scenes = append(st.Scenes, groups...)
+}
+
+// Function rename renames a source everywhere it is referenced.
+func (st *StateSnapshot) rename(from, to string) {
+	if src, ok := st.Sources[from]; ok {
+		delete(st.Sources, from)
+		src.Name = to
+		st.Sources[to] = src
+	}
+	for i := range st.Scenes {
+		sc := &st.Scenes[i]
+		if sc.Name == from {
+			sc.Name = to
+		}
+		for j := range sc.Items {
+			item := &sc.Items[j]
+			if item.SourceName == from {
+				item.SourceName = to
+			}
+			if item.ParentGroupName == from {
+				item.ParentGroupName = to
+			}
+		}
+	}
+	if st.CurrentScene == from {
+		st.CurrentScene = to
+	}
+	if st.PreviewScene == from {
+		st.PreviewScene = to
+	}
+}
+
+// Function Scene returns the scene or group with the given name.
+func (st StateSnapshot) Scene(name string) (StateScene, bool) {
+	if sc := st.scene(name); sc != nil {
+		return *sc, true
+	}
+	return StateScene{}, false
+}
+
+func (st *StateSnapshot) scene(name string) *StateScene {
+	for i := range st.Scenes {
+		if st.Scenes[i].Name == name {
+			return &st.Scenes[i]
+		}
+	}
+	return nil
+}
+
+func (st *StateSnapshot) item(scene string, id int) *StateSceneItem {
+	if sc := st.scene(scene); sc != nil {
+		return sc.item(id)
+	}
+	return nil
+}
+
+func (st StateSnapshot) clone() StateSnapshot {
+	out := st
+	out.Transitions = append([]string(nil), st.Transitions...)
+	out.Scenes = make([]StateScene, len(st.Scenes))
+	for i, sc := range st.Scenes {
+		out.Scenes[i] = sc.clone()
+	}
+	out.Sources = make(map[string]StateSource, len(st.Sources))
+	for k, v := range st.Sources {
+		out.Sources[k] = v.clone()
+	}
+	return out
+}
+
+func (sc *StateScene) item(id int) *StateSceneItem {
+	for i := range sc.Items {
+		if sc.Items[i].Id == id {
+			return &sc.Items[i]
+		}
+	}
+	return nil
+}
+
+func (sc StateScene) clone() StateScene {
+	out := sc
+	out.Items = make([]StateSceneItem, len(sc.Items))
+	for i, item := range sc.Items {
+		if item.Transform != nil {
+			t := *item.Transform
+			item.Transform = &t
+		}
+		out.Items[i] = item
+	}
+	return out
+}
+
+func (src *StateSource) filter(name string) *StateFilter {
+	for i := range src.Filters {
+		if src.Filters[i].Name == name {
+			return &src.Filters[i]
+		}
+	}
+	return nil
+}
+
+func (src StateSource) clone() StateSource {
+	out := src
+	out.Filters = append([]StateFilter(nil), src.Filters...)
+	return out
+}
+
+// Function updateType returns the update type of an event.
+func updateType(event any) string {
+	if e, ok := event.(interface{ updateType() string }); ok {
+		return e.updateType()
+	}
+	return ""
+}
+
+func (e eventData) updateType() string {
+	return e.UpdateType
+}
@@ -0,0 +1,185 @@
+package go_obs_test
+
+import (
+	"sync"
+	"testing"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function fakeState answers the requests a state cache makes while
+// loading. It has two scenes and a microphone with a noise gate.
+func fakeState(f *fakeOBS) {
+	f.reply("GetSourceTypesList", map[string]any{"types": []any{
+		map[string]any{"typeId": "pulse_input_capture", "caps": map[string]any{"hasAudio": true}},
+		map[string]any{"typeId": "image_source", "caps": map[string]any{"hasAudio": false}},
+	}})
+	f.reply("GetSourcesList", map[string]any{"sources": []any{
+		map[string]any{"name": "Mic", "typeId": "pulse_input_capture", "type": "input"},
+		map[string]any{"name": "Logo", "typeId": "image_source", "type": "input"},
+	}})
+	f.reply("GetVolume", map[string]any{"name": "Mic", "volume": 0.5, "muted": true})
+	f.handle("GetSourceFilters", func(req map[string]any) (map[string]any, error) {
+		if req["sourceName"] != "Mic" {
+			return map[string]any{"filters": []any{}}, nil
+		}
+		return map[string]any{"filters": []any{
+			map[string]any{"name": "Gate", "type": "noise_gate_filter", "enabled": true, "settings": map[string]any{}},
+		}}, nil
+	})
+	f.reply("GetSceneList", map[string]any{
+		"current-scene": "Main",
+		"scenes": []any{
+			map[string]any{"name": "Main", "sources": []any{
+				map[string]any{"id": 1, "name": "Mic", "type": "input", "render": true},
+			}},
+			map[string]any{"name": "BRB", "sources": []any{}},
+		},
+	})
+	f.reply("GetStudioModeStatus", map[string]any{"studio-mode": false})
+	f.reply("GetTransitionList", map[string]any{
+		"current-transition": "Fade",
+		"transitions":        []any{map[string]any{"name": "Fade"}, map[string]any{"name": "Cut"}},
+	})
+	f.reply("GetTransitionDuration", map[string]any{"transition-duration": 300})
+	f.reply("GetSceneItemProperties", map[string]any{"name": "Logo", "itemId": 2, "visible": false, "locked": true})
+}
+
+func TestStateCacheLoad(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeState(f)
+	s, err := obs.NewStateCache(f.connect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	snap := s.Snapshot()
+	if snap.CurrentScene != "Main" || snap.CurrentTransition != "Fade" || snap.TransitionDuration != 300 {
+		t.Errorf("state: %+v", snap)
+	}
+	if len(snap.Transitions) != 2 || len(snap.Scenes) != 2 {
+		t.Errorf("transitions %v, scenes %v", snap.Transitions, snap.Scenes)
+	}
+	main, ok := s.Scene("Main")
+	if !ok || len(main.Items) != 1 || main.Items[0].SourceName != "Mic" || !main.Items[0].Visible {
+		t.Errorf("Main: %+v", main)
+	}
+	mic, ok := s.Source("Mic")
+	if !ok || !mic.Audio || !mic.Muted || mic.Volume != 0.5 || len(mic.Filters) != 1 {
+		t.Errorf("Mic: %+v", mic)
+	}
+	if logo, _ := s.Source("Logo"); logo.Audio {
+		t.Errorf("Logo: %+v", logo)
+	}
+}
+
+func TestStateCacheEvents(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeState(f)
+	s, err := obs.NewStateCache(f.connect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	var mx sync.Mutex
+	var changes []string
+	s.OnChange(func(change obs.StateChange) {
+		mx.Lock()
+		changes = append(changes, change.UpdateType)
+		mx.Unlock()
+	})
+
+	f.event("SwitchScenes", map[string]any{"scene-name": "BRB", "sources": []any{}})
+	waitFor(t, "scene switch", func() bool { return s.CurrentScene() == "BRB" })
+
+	f.event("SceneItemAdded", map[string]any{"scene-name": "Main", "item-name": "Logo", "item-id": 2})
+	waitFor(t, "item properties", func() bool {
+		main, _ := s.Scene("Main")
+		return len(main.Items) == 2 && main.Items[0].Transform != nil
+	})
+	main, _ := s.Scene("Main")
+	if item := main.Items[0]; item.Id != 2 || item.Visible || !item.Locked {
+		t.Errorf("added item: %+v", item)
+	}
+
+	f.event("SourceRenamed", map[string]any{"previousName": "Mic", "newName": "Microphone", "sourceType": "input"})
+	waitFor(t, "rename", func() bool {
+		_, ok := s.Source("Microphone")
+		return ok
+	})
+	if _, ok := s.Source("Mic"); ok {
+		t.Error("old name is still cached")
+	}
+	main, _ = s.Scene("Main")
+	if main.Items[1].SourceName != "Microphone" {
+		t.Errorf("renamed item: %+v", main.Items[1])
+	}
+
+	f.event("SourceVolumeChanged", map[string]any{"sourceName": "Microphone", "volume": 0.25, "volumeDb": -12})
+	waitFor(t, "volume", func() bool {
+		mic, _ := s.Source("Microphone")
+		return mic.Volume == 0.25 && mic.VolumeDb == -12
+	})
+
+	// The added item is reported twice: once for the event, and once
+	// when its properties have been fetched.
+	want := []string{"SwitchScenes", "SceneItemAdded", "SceneItemAdded", "SourceRenamed", "SourceVolumeChanged"}
+	mx.Lock()
+	defer mx.Unlock()
+	if len(changes) != len(want) {
+		t.Fatalf("changes: %v", changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes: %v", changes)
+			break
+		}
+	}
+}
+
+func TestStateCacheEventsDuringReload(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeState(f)
+	s, err := obs.NewStateCache(f.connect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	reloaded := make(chan struct{}, 1)
+	s.OnChange(func(change obs.StateChange) {
+		if change.UpdateType == "" {
+			reloaded <- struct{}{}
+		}
+	})
+
+	// Events sent before the scene list are queued until it has loaded,
+	// and then applied on top of it.
+	f.handle("GetSceneList", func(map[string]any) (map[string]any, error) {
+		f.event("SwitchScenes", map[string]any{"scene-name": "BRB", "sources": []any{}})
+		f.event("SceneItemAdded", map[string]any{"scene-name": "Main", "item-name": "Logo", "item-id": 2})
+		return map[string]any{
+			"current-scene": "Main",
+			"scenes": []any{
+				map[string]any{"name": "Main", "sources": []any{
+					map[string]any{"id": 1, "name": "Mic", "type": "input", "render": true},
+				}},
+				map[string]any{"name": "BRB", "sources": []any{}},
+			},
+		}, nil
+	})
+	if err := s.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	<-reloaded
+	if s.CurrentScene() != "BRB" {
+		t.Errorf("current scene: %s", s.CurrentScene())
+	}
+	waitFor(t, "item properties", func() bool {
+		main, _ := s.Scene("Main")
+		return len(main.Items) == 2 && main.Items[0].Transform != nil
+	})
+	if n := len(f.sent("GetSceneItemProperties")); n != 1 {
+		t.Errorf("properties fetched %d times", n)
+	}
+}
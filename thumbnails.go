@@ -0,0 +1,316 @@
+package go_obs
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Thumbnail is a screenshot of a scene or source taken by a thumbnail
+// capture.
+type Thumbnail struct {
+	Source string
+	// Time the screenshot was taken.
+	Time time.Time
+	// JPEG encoded image.
+	JPEG []byte
+}
+
+// Function Image decodes the thumbnail.
+func (t *Thumbnail) Image() (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(t.JPEG))
+	return img, err
+}
+
+// ThumbnailCapture periodically takes screenshots of a set of scenes or
+// sources with TakeSourceScreenshot, and keeps the latest one of each. At
+// most a limited number of screenshots are requested at once, and a source
+// is not captured again while its previous screenshot is still pending.
+type ThumbnailCapture struct {
+	c         *Client
+	width     int
+	height    int
+	sem       chan struct{}
+	mx        sync.Mutex
+	quality   int
+	sources   map[string]bool
+	pending   map[string]bool
+	cache     map[string]*Thumbnail
+	onCapture map[int]func(t *Thumbnail)
+	onError   map[int]func(source string, err error)
+	nextId    int
+	done      chan struct{}
+	once      sync.Once
+}
+
+// Function NewThumbnailCapture creates a thumbnail capture which takes
+// screenshots every interval, at most concurrency at a time. If width or
+// height is zero, it is computed from the other to keep the aspect ratio of
+// the source; if both are zero, the base size of the source is used. If
+// interval is not positive, screenshots are only taken when sources are
+// added.
+func NewThumbnailCapture(c *Client, width, height int, interval time.Duration, concurrency int) *ThumbnailCapture {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	t := &ThumbnailCapture{
+		c:         c,
+		width:     width,
+		height:    height,
+		quality:   75,
+		sem:       make(chan struct{}, concurrency),
+		sources:   make(map[string]bool),
+		pending:   make(map[string]bool),
+		cache:     make(map[string]*Thumbnail),
+		onCapture: make(map[int]func(*Thumbnail)),
+		onError:   make(map[int]func(string, error)),
+		done:      make(chan struct{}),
+	}
+	if interval > 0 {
+		go t.run(interval)
+	}
+	return t
+}
+
+// Function Close stops capturing. Pending screenshots are not interrupted,
+// but their results are discarded.
+func (t *ThumbnailCapture) Close() {
+	t.once.Do(func() {
+		close(t.done)
+	})
+}
+
+// Function SetQuality sets the JPEG quality (1-100) of the screenshots. It
+// defaults to 75.
+func (t *ThumbnailCapture) SetQuality(quality int) {
+	if quality <= 0 || quality > 100 {
+		quality = 75
+	}
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	t.quality = quality
+}
+
+// Function Add starts capturing the given scenes or sources. The first
+// screenshot of each is taken immediately.
+func (t *ThumbnailCapture) Add(sources ...string) {
+	t.mx.Lock()
+	for _, s := range sources {
+		t.sources[s] = true
+	}
+	t.mx.Unlock()
+	for _, s := range sources {
+		t.capture(s)
+	}
+}
+
+// Function AddScenes starts capturing every scene in the current scene
+// collection.
+func (t *ThumbnailCapture) AddScenes() error {
+	res, err := t.c.GetSceneList()
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(res.Scenes))
+	for i, s := range res.Scenes {
+		names[i] = s.Name
+	}
+	t.Add(names...)
+	return nil
+}
+
+// Function Remove stops capturing the given scenes or sources and drops
+// their thumbnails.
+func (t *ThumbnailCapture) Remove(sources ...string) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	for _, s := range sources {
+		delete(t.sources, s)
+		delete(t.cache, s)
+	}
+}
+
+// Function Sources returns the scenes and sources being captured, sorted.
+func (t *ThumbnailCapture) Sources() []string {
+	t.mx.Lock()
+	out := make([]string, 0, len(t.sources))
+	for s := range t.sources {
+		out = append(out, s)
+	}
+	t.mx.Unlock()
+	sort.Strings(out)
+	return out
+}
+
+// Function Latest returns the latest thumbnail of a scene or source, or nil
+// if none has been taken yet.
+func (t *ThumbnailCapture) Latest(source string) *Thumbnail {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	return t.cache[source]
+}
+
+// Function All returns the latest thumbnail of every scene and source which
+// has one.
+func (t *ThumbnailCapture) All() map[string]*Thumbnail {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	out := make(map[string]*Thumbnail, len(t.cache))
+	for k, v := range t.cache {
+		out[k] = v
+	}
+	return out
+}
+
+// Function JPEG returns the latest thumbnail of a scene or source as JPEG
+// bytes.
+func (t *ThumbnailCapture) JPEG(source string) ([]byte, error) {
+	thumb := t.Latest(source)
+	if thumb == nil {
+		return nil, errors.New("no thumbnail for source: " + source)
+	}
+	return thumb.JPEG, nil
+}
+
+// Function Image returns the latest thumbnail of a scene or source, decoded.
+func (t *ThumbnailCapture) Image(source string) (image.Image, error) {
+	thumb := t.Latest(source)
+	if thumb == nil {
+		return nil, errors.New("no thumbnail for source: " + source)
+	}
+	return thumb.Image()
+}
+
+// Function OnCapture registers fn to be called whenever a thumbnail is
+// taken. The returned function unregisters the callback.
+func (t *ThumbnailCapture) OnCapture(fn func(t *Thumbnail)) func() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	id := t.nextId
+	t.nextId++
+	t.onCapture[id] = fn
+	return func() {
+		t.mx.Lock()
+		defer t.mx.Unlock()
+		delete(t.onCapture, id)
+	}
+}
+
+// Function OnError registers fn to be called whenever a screenshot fails.
+// The returned function unregisters the callback.
+func (t *ThumbnailCapture) OnError(fn func(source string, err error)) func() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	id := t.nextId
+	t.nextId++
+	t.onError[id] = fn
+	return func() {
+		t.mx.Lock()
+		defer t.mx.Unlock()
+		delete(t.onError, id)
+	}
+}
+
+func (t *ThumbnailCapture) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+		}
+		for _, s := range t.Sources() {
+			t.capture(s)
+		}
+	}
+}
+
+// Function capture starts taking a screenshot of a source, unless one is
+// already pending.
+func (t *ThumbnailCapture) capture(source string) {
+	t.mx.Lock()
+	if t.pending[source] {
+		t.mx.Unlock()
+		return
+	}
+	t.pending[source] = true
+	t.mx.Unlock()
+
+	go func() {
+		defer func() {
+			t.mx.Lock()
+			delete(t.pending, source)
+			t.mx.Unlock()
+		}()
+		select {
+		case t.sem <- struct{}{}:
+		case <-t.done:
+			return
+		}
+		thumb, err := t.take(source)
+		<-t.sem
+
+		select {
+		case <-t.done:
+			return
+		default:
+		}
+		t.mx.Lock()
+		if !t.sources[source] {
+			// Removed while the screenshot was pending.
+			t.mx.Unlock()
+			return
+		}
+		if err == nil {
+			t.cache[source] = thumb
+		}
+		onCapture := make([]func(*Thumbnail), 0, len(t.onCapture))
+		for _, fn := range t.onCapture {
+			onCapture = append(onCapture, fn)
+		}
+		onError := make([]func(string, error), 0, len(t.onError))
+		for _, fn := range t.onError {
+			onError = append(onError, fn)
+		}
+		t.mx.Unlock()
+
+		if err != nil {
+			for _, fn := range onError {
+				fn(source, err)
+			}
+			return
+		}
+		for _, fn := range onCapture {
+			fn(thumb)
+		}
+	}()
+}
+
+func (t *ThumbnailCapture) take(source string) (*Thumbnail, error) {
+	if err := t.c.checkImageFormat("jpg"); err != nil {
+		return nil, err
+	}
+	var width, height *int
+	if t.width > 0 {
+		width = ptr(t.width)
+	}
+	if t.height > 0 {
+		height = ptr(t.height)
+	}
+	t.mx.Lock()
+	quality := t.quality
+	t.mx.Unlock()
+	res, err := t.c.TakeSourceScreenshot(source, "jpg", "", "", &quality, width, height)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := res.Data()
+	if err != nil {
+		return nil, err
+	}
+	return &Thumbnail{Source: source, Time: time.Now(), JPEG: data}, nil
+}
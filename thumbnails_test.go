@@ -0,0 +1,136 @@
+package go_obs_test
+
+import (
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function fakeScreenshots answers TakeSourceScreenshot once release is
+// closed or sent to, and returns the most screenshots which were pending at
+// once.
+func fakeScreenshots(f *fakeOBS, release chan struct{}) func() int {
+	f.reply("GetVersion", map[string]any{"supported-image-export-formats": "png,jpg,jpeg"})
+	var mx sync.Mutex
+	var pending, most int
+	f.handle("TakeSourceScreenshot", func(req map[string]any) (map[string]any, error) {
+		mx.Lock()
+		pending++
+		if pending > most {
+			most = pending
+		}
+		mx.Unlock()
+		<-release
+		mx.Lock()
+		pending--
+		mx.Unlock()
+		img := "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString([]byte(req["sourceName"].(string)))
+		return map[string]any{"sourceName": req["sourceName"], "img": img}, nil
+	})
+	return func() int {
+		mx.Lock()
+		defer mx.Unlock()
+		return most
+	}
+}
+
+// Function waitFor polls cond until it is true, or fails the test after a
+// second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for start := time.Now(); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatal("timed out waiting for " + what)
+		}
+	}
+}
+
+func TestThumbnailPending(t *testing.T) {
+	f := newFakeOBS(t)
+	release := make(chan struct{})
+	fakeScreenshots(f, release)
+	c := f.connect()
+	// Without an interval, screenshots are only taken when sources are
+	// added.
+	thumbs := obs.NewThumbnailCapture(c, 0, 0, 0, 4)
+	defer thumbs.Close()
+	thumbs.SetQuality(90)
+	captured := make(chan *obs.Thumbnail, 4)
+	thumbs.OnCapture(func(t *obs.Thumbnail) {
+		captured <- t
+	})
+
+	thumbs.Add("Main", "Camera")
+	waitFor(t, "screenshots", func() bool { return len(f.sent("TakeSourceScreenshot")) == 2 })
+	// Main is still pending, so it is not captured again.
+	thumbs.Add("Main")
+	thumbs.Remove("Camera")
+	time.Sleep(50 * time.Millisecond)
+	if n := len(f.sent("TakeSourceScreenshot")); n != 2 {
+		t.Errorf("%d screenshots requested", n)
+	}
+	if q := f.sent("TakeSourceScreenshot")[0]["compressionQuality"]; q != 90.0 {
+		t.Errorf("quality: %v", q)
+	}
+
+	close(release)
+	select {
+	case thumb := <-captured:
+		if thumb.Source != "Main" || string(thumb.JPEG) != "Main" {
+			t.Errorf("captured %+v", thumb)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no capture")
+	}
+	// Camera was removed while pending, so its screenshot is dropped.
+	select {
+	case thumb := <-captured:
+		t.Errorf("captured removed source %s", thumb.Source)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if thumbs.Latest("Camera") != nil || thumbs.Latest("Main") == nil {
+		t.Errorf("thumbnails: %v", thumbs.All())
+	}
+	if sources := thumbs.Sources(); len(sources) != 1 || sources[0] != "Main" {
+		t.Errorf("sources: %v", sources)
+	}
+}
+
+func TestThumbnailConcurrency(t *testing.T) {
+	f := newFakeOBS(t)
+	release := make(chan struct{})
+	most := fakeScreenshots(f, release)
+	c := f.connect()
+	thumbs := obs.NewThumbnailCapture(c, 160, 0, time.Hour, 2)
+	defer thumbs.Close()
+	var mx sync.Mutex
+	captured := 0
+	thumbs.OnCapture(func(*obs.Thumbnail) {
+		mx.Lock()
+		captured++
+		mx.Unlock()
+	})
+
+	sources := []string{"A", "B", "C", "D", "E"}
+	thumbs.Add(sources...)
+	go func() {
+		for range sources {
+			time.Sleep(20 * time.Millisecond)
+			release <- struct{}{}
+		}
+	}()
+	waitFor(t, "captures", func() bool {
+		mx.Lock()
+		defer mx.Unlock()
+		return captured == len(sources)
+	})
+	if n := most(); n != 2 {
+		t.Errorf("%d screenshots pending at once, want 2", n)
+	}
+	if w := f.sent("TakeSourceScreenshot")[0]["width"]; w != 160.0 {
+		t.Errorf("width: %v", w)
+	}
+}
@@ -0,0 +1,79 @@
+package go_obs
+
+import "math"
+
+// ItemTransform describes the transform of a scene item, as accepted by
+// SetSceneItemProperties. Fields which are nil or empty are left unchanged.
+type ItemTransform struct {
+	Position SetSceneItemPropertiesPosition `json:"position"`
+	Rotation *float64                       `json:"rotation,omitempty"`
+	Scale    SetSceneItemPropertiesScale    `json:"scale"`
+	Crop     SetSceneItemPropertiesCrop     `json:"crop"`
+	Bounds   SetSceneItemPropertiesBounds   `json:"bounds"`
+}
+
+// Function SetItemTransform applies a transform to a scene item. The
+// visibility and lock state are left unchanged if nil.
+func (c *Client) SetItemTransform(sceneName string, item ItemRef, t ItemTransform, visible *bool, locked *bool) error {
+	_, err := c.SetSceneItemProperties(sceneName, item, t.Position, t.Rotation, t.Scale, t.Crop, visible, locked, t.Bounds)
+	return err
+}
+
+// Function ItemTransformFromProperties returns the complete transform of a
+// scene item from its properties.
+func ItemTransformFromProperties(res *GetSceneItemPropertiesResponse) ItemTransform {
+	return ItemTransform{
+		Position: SetSceneItemPropertiesPosition{
+			X:         ptr(res.Position.X),
+			Y:         ptr(res.Position.Y),
+			Alignment: ptr(res.Position.Alignment),
+		},
+		Rotation: ptr(res.Rotation),
+		Scale: SetSceneItemPropertiesScale{
+			X:      ptr(res.Scale.X),
+			Y:      ptr(res.Scale.Y),
+			Filter: res.Scale.Filter,
+		},
+		Crop: SetSceneItemPropertiesCrop{
+			Top:    ptr(res.Crop.Top),
+			Bottom: ptr(res.Crop.Bottom),
+			Left:   ptr(res.Crop.Left),
+			Right:  ptr(res.Crop.Right),
+		},
+		Bounds: SetSceneItemPropertiesBounds{
+			Type:      res.Bounds.Type,
+			Alignment: ptr(res.Bounds.Alignment),
+			X:         ptr(res.Bounds.X),
+			Y:         ptr(res.Bounds.Y),
+		},
+	}
+}
+
+// Function Matches returns whether every field set in the transform
+// matches the given scene item properties.
+func (t ItemTransform) Matches(res *GetSceneItemPropertiesResponse) bool {
+	p, s, c, b := t.Position, t.Scale, t.Crop, t.Bounds
+	return matchFloat(p.X, res.Position.X) &&
+		matchFloat(p.Y, res.Position.Y) &&
+		matchValue(p.Alignment, res.Position.Alignment) &&
+		matchFloat(t.Rotation, res.Rotation) &&
+		matchFloat(s.X, res.Scale.X) &&
+		matchFloat(s.Y, res.Scale.Y) &&
+		(s.Filter == "" || s.Filter == res.Scale.Filter) &&
+		matchValue(c.Top, res.Crop.Top) &&
+		matchValue(c.Bottom, res.Crop.Bottom) &&
+		matchValue(c.Left, res.Crop.Left) &&
+		matchValue(c.Right, res.Crop.Right) &&
+		(b.Type == "" || b.Type == res.Bounds.Type) &&
+		matchValue(b.Alignment, res.Bounds.Alignment) &&
+		matchFloat(b.X, res.Bounds.X) &&
+		matchFloat(b.Y, res.Bounds.Y)
+}
+
+func matchFloat(want *float64, have float64) bool {
+	return want == nil || math.Abs(*want-have) < 1e-3
+}
+
+func matchValue[T comparable](want *T, have T) bool {
+	return want == nil || *want == have
+}
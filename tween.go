@@ -0,0 +1,246 @@
+package go_obs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Easing maps the linear progress of an animation (0 to 1) to the progress
+// of the animated values.
+type Easing func(t float64) float64
+
+var (
+	EaseLinear    Easing = func(t float64) float64 { return t }
+	EaseInQuad    Easing = func(t float64) float64 { return t * t }
+	EaseOutQuad   Easing = func(t float64) float64 { return t * (2 - t) }
+	EaseInOutQuad Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 2 * t * t
+		}
+		return 1 - 2*(1-t)*(1-t)
+	}
+	EaseInCubic    Easing = func(t float64) float64 { return t * t * t }
+	EaseOutCubic   Easing = func(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+	EaseInOutCubic Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		return 1 - 4*math.Pow(1-t, 3)
+	}
+)
+
+// Tweener animates the transforms of scene items. Every frame, the current
+// values of all running animations are sent in a single ExecuteBatch
+// request. If OBS takes longer than a frame to respond, frames are dropped
+// rather than queued, so the connection is never flooded.
+type Tweener struct {
+	c       *Client
+	frame   time.Duration
+	mx      sync.Mutex
+	tweens  map[string]*tween
+	running bool
+}
+
+type tween struct {
+	scene    string
+	item     ItemRef
+	from, to ItemTransform
+	start    time.Time
+	duration time.Duration
+	ease     Easing
+	done     chan error
+	once     sync.Once
+}
+
+func (t *tween) finish(err error) {
+	t.once.Do(func() {
+		t.done <- err
+	})
+}
+
+// Function NewTweener creates a tweener which updates animated items at the
+// given frame rate.
+func NewTweener(c *Client, fps int) *Tweener {
+	if fps <= 0 {
+		fps = 30
+	}
+	return &Tweener{
+		c:      c,
+		frame:  time.Second / time.Duration(fps),
+		tweens: make(map[string]*tween),
+	}
+}
+
+// Function Animate changes the transform of a scene item to the target over
+// the given duration, following the easing function (EaseLinear if nil).
+// Position, rotation, scale and crop fields which are set in the target are
+// animated; position alignment, scale filter and bounds are applied with the
+// last frame. It blocks until the animation completes, ctx is canceled, or
+// another animation of the same item replaces it.
+func (tw *Tweener) Animate(ctx context.Context, sceneName string, item ItemRef, to ItemTransform, duration time.Duration, ease Easing) error {
+	props, err := tw.c.GetSceneItemProperties(sceneName, item)
+	if err != nil {
+		return err
+	}
+	if ease == nil {
+		ease = EaseLinear
+	}
+	t := &tween{
+		scene:    sceneName,
+		item:     ItemByNameAndId(props.Name, props.ItemId),
+		from:     ItemTransformFromProperties(props),
+		to:       to,
+		start:    time.Now(),
+		duration: duration,
+		ease:     ease,
+		done:     make(chan error, 1),
+	}
+	key := sceneName + "\x00" + strconv.Itoa(props.ItemId)
+
+	tw.mx.Lock()
+	if old, ok := tw.tweens[key]; ok {
+		old.finish(errors.New("animation replaced"))
+	}
+	tw.tweens[key] = t
+	if !tw.running {
+		tw.running = true
+		go tw.run()
+	}
+	tw.mx.Unlock()
+
+	select {
+	case err := <-t.done:
+		return err
+	case <-ctx.Done():
+		tw.remove(key, t)
+		return ctx.Err()
+	}
+}
+
+// Function Stop cancels every running animation.
+func (tw *Tweener) Stop() {
+	tw.mx.Lock()
+	defer tw.mx.Unlock()
+	for key, t := range tw.tweens {
+		t.finish(context.Canceled)
+		delete(tw.tweens, key)
+	}
+}
+
+func (tw *Tweener) remove(key string, t *tween) {
+	tw.mx.Lock()
+	if tw.tweens[key] == t {
+		delete(tw.tweens, key)
+	}
+	tw.mx.Unlock()
+}
+
+func (tw *Tweener) run() {
+	ticker := time.NewTicker(tw.frame)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		var reqs []BatchRequest
+		var sent []*tween
+		var last []bool
+		tw.mx.Lock()
+		if len(tw.tweens) == 0 {
+			tw.running = false
+			tw.mx.Unlock()
+			return
+		}
+		for key, t := range tw.tweens {
+			k := 1.0
+			if t.duration > 0 {
+				k = math.Min(float64(now.Sub(t.start))/float64(t.duration), 1)
+			}
+			frame := t.to
+			if k < 1 {
+				frame = lerpTransform(t.from, t.to, t.ease(k))
+			} else {
+				delete(tw.tweens, key)
+			}
+			reqs = append(reqs, BatchRequest{
+				RequestType: "SetSceneItemProperties",
+				Params: SetSceneItemPropertiesRequest{
+					SceneName: t.scene,
+					Item:      t.item,
+					Position:  frame.Position,
+					Rotation:  frame.Rotation,
+					Scale:     frame.Scale,
+					Crop:      frame.Crop,
+					Bounds:    frame.Bounds,
+				},
+			})
+			sent = append(sent, t)
+			last = append(last, k == 1)
+		}
+		tw.mx.Unlock()
+
+		res, err := tw.c.Batch(reqs, false)
+		for i, t := range sent {
+			if res == nil {
+				t.finish(err)
+			} else if i < len(res.Results) && res.Results[i].Status == "error" {
+				t.finish(errors.New(res.Results[i].Error))
+			} else if last[i] {
+				t.finish(nil)
+			} else {
+				continue
+			}
+			tw.mx.Lock()
+			for key, v := range tw.tweens {
+				if v == t {
+					delete(tw.tweens, key)
+				}
+			}
+			tw.mx.Unlock()
+		}
+
+		<-ticker.C
+	}
+}
+
+// Function lerpTransform interpolates the fields set in to, with k between
+// 0 (from) and 1 (to). Fields which are not set in to are left nil.
+func lerpTransform(from, to ItemTransform, k float64) ItemTransform {
+	f := func(a, b *float64) *float64 {
+		if b == nil {
+			return nil
+		}
+		if a == nil {
+			return b
+		}
+		return ptr(*a + (*b-*a)*k)
+	}
+	i := func(a, b *int) *int {
+		if b == nil {
+			return nil
+		}
+		if a == nil {
+			return b
+		}
+		return ptr(int(math.Round(float64(*a) + float64(*b-*a)*k)))
+	}
+	return ItemTransform{
+		Position: SetSceneItemPropertiesPosition{
+			X: f(from.Position.X, to.Position.X),
+			Y: f(from.Position.Y, to.Position.Y),
+		},
+		Rotation: f(from.Rotation, to.Rotation),
+		Scale: SetSceneItemPropertiesScale{
+			X: f(from.Scale.X, to.Scale.X),
+			Y: f(from.Scale.Y, to.Scale.Y),
+		},
+		Crop: SetSceneItemPropertiesCrop{
+			Top:    i(from.Crop.Top, to.Crop.Top),
+			Bottom: i(from.Crop.Bottom, to.Crop.Bottom),
+			Left:   i(from.Crop.Left, to.Crop.Left),
+			Right:  i(from.Crop.Right, to.Crop.Right),
+		},
+	}
+}
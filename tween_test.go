@@ -0,0 +1,141 @@
+package go_obs_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+// Function fakeTweenItems answers GetSceneItemProperties for items named
+// "A" and "B" at the origin, and returns the batches of requests received.
+func fakeTweenItems(f *fakeOBS) func() [][]map[string]any {
+	f.handle("GetSceneItemProperties", func(req map[string]any) (map[string]any, error) {
+		name := itemName(req["item"])
+		return map[string]any{
+			"name":     name,
+			"itemId":   int(name[0]),
+			"position": map[string]any{"x": 0, "y": 0, "alignment": 5},
+			"scale":    map[string]any{"x": 1, "y": 1},
+			"crop":     map[string]any{"top": 0, "bottom": 0, "left": 0, "right": 0},
+		}, nil
+	})
+	var mx sync.Mutex
+	var batches [][]map[string]any
+	f.handle("ExecuteBatch", func(req map[string]any) (map[string]any, error) {
+		var batch []map[string]any
+		var results []any
+		for _, v := range req["requests"].([]any) {
+			batch = append(batch, v.(map[string]any))
+			results = append(results, map[string]any{"status": "ok"})
+		}
+		mx.Lock()
+		batches = append(batches, batch)
+		mx.Unlock()
+		return map[string]any{"results": results}, nil
+	})
+	return func() [][]map[string]any {
+		mx.Lock()
+		defer mx.Unlock()
+		return batches
+	}
+}
+
+// Function itemName returns the name in an item reference, which is sent
+// either as a name or as an object.
+func itemName(ref any) string {
+	if m, ok := ref.(map[string]any); ok {
+		name, _ := m["name"].(string)
+		return name
+	}
+	name, _ := ref.(string)
+	return name
+}
+
+func TestTweenerBatches(t *testing.T) {
+	f := newFakeOBS(t)
+	batches := fakeTweenItems(f)
+	c := f.connect()
+	tw := obs.NewTweener(c, 50)
+
+	x, top := 100.0, 7
+	to := obs.ItemTransform{
+		Position: obs.SetSceneItemPropertiesPosition{X: &x},
+		Crop:     obs.SetSceneItemPropertiesCrop{Top: &top},
+	}
+	errs := make(chan error, 2)
+	for _, name := range []string{"A", "B"} {
+		go func(name string) {
+			errs <- tw.Animate(context.Background(), "Main", obs.ItemByName(name), to, 200*time.Millisecond, obs.EaseInOutQuad)
+		}(name)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	together := false
+	last := map[string]float64{}
+	for _, batch := range batches() {
+		if len(batch) > 2 {
+			t.Errorf("batch of %d requests", len(batch))
+		}
+		together = together || len(batch) == 2
+		for _, req := range batch {
+			name := itemName(req["item"])
+			x := req["position"].(map[string]any)["x"].(float64)
+			top := req["crop"].(map[string]any)["top"].(float64)
+			if req["request-type"] != "SetSceneItemProperties" || req["scene-name"] != "Main" {
+				t.Errorf("request: %v", req)
+			}
+			if x < last[name] || x > 100 {
+				t.Errorf("%s: x went from %v to %v", name, last[name], x)
+			}
+			if top != math.Trunc(top) {
+				t.Errorf("%s: fractional crop %v", name, top)
+			}
+			if _, ok := req["rotation"]; ok {
+				t.Errorf("%s: unset rotation animated", name)
+			}
+			last[name] = x
+		}
+	}
+	if !together {
+		t.Error("items were never updated in the same batch")
+	}
+	if last["A"] != 100 || last["B"] != 100 {
+		t.Errorf("final positions: %v", last)
+	}
+}
+
+func TestTweenerReplace(t *testing.T) {
+	f := newFakeOBS(t)
+	fakeTweenItems(f)
+	c := f.connect()
+	tw := obs.NewTweener(c, 50)
+
+	x := 100.0
+	to := obs.ItemTransform{Position: obs.SetSceneItemPropertiesPosition{X: &x}}
+	first := make(chan error)
+	go func() {
+		first <- tw.Animate(context.Background(), "Main", obs.ItemByName("A"), to, time.Second, nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if err := tw.Animate(context.Background(), "Main", obs.ItemByName("A"), to, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-first; err == nil || !strings.Contains(err.Error(), "replaced") {
+		t.Errorf("first animation: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tw.Animate(ctx, "Main", obs.ItemByName("B"), to, time.Second, nil); err != context.DeadlineExceeded {
+		t.Errorf("canceled animation: %v", err)
+	}
+}
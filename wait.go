@@ -0,0 +1,130 @@
+package go_obs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// EventWaiter waits for the first event of type T which matches a
+// predicate. T must be a pointer to one of the event types, such as
+// *TransitionEndEvent.
+type EventWaiter[T any] struct {
+	ch     chan T
+	once   sync.Once
+	mx     sync.Mutex
+	remove func()
+	// Whether the waiter was stopped, possibly before its listener was
+	// registered.
+	stopped bool
+}
+
+// Function NewEventWaiter starts listening for the first event of type T
+// for which predicate returns true. A nil predicate matches any event. The
+// predicate runs on the client's read loop, so it must not block on
+// requests. Listening starts immediately, so a waiter created before
+// sending a request cannot miss the events it causes.
+func NewEventWaiter[T any](c *Client, predicate func(T) bool) (*EventWaiter[T], error) {
+	name, err := eventName[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &EventWaiter[T]{ch: make(chan T, 1)}
+	remove := c.addListener(name, func(event any) {
+		e, ok := event.(T)
+		if !ok || (predicate != nil && !predicate(e)) {
+			return
+		}
+		w.once.Do(func() {
+			w.ch <- e
+			w.Cancel()
+		})
+	})
+
+	// An event may have arrived before addListener returned.
+	w.mx.Lock()
+	w.remove = remove
+	stopped := w.stopped
+	w.mx.Unlock()
+	if stopped {
+		remove()
+	}
+	return w, nil
+}
+
+// Function Wait blocks until a matching event arrives or ctx is done.
+func (w *EventWaiter[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case e := <-w.ch:
+		return e, nil
+	case <-ctx.Done():
+		w.Cancel()
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Function Cancel stops listening for events.
+func (w *EventWaiter[T]) Cancel() {
+	w.mx.Lock()
+	remove := w.remove
+	w.stopped = true
+	w.mx.Unlock()
+	if remove != nil {
+		remove()
+	}
+}
+
+// Function WaitFor blocks until an event of type T for which predicate
+// returns true arrives, or ctx is done. A nil predicate matches any event.
+//
+//	evt, err := obs.WaitFor(ctx, c, func(e *obs.MediaEndedEvent) bool {
+//		return e.SourceName == "Intro"
+//	})
+func WaitFor[T any](ctx context.Context, c *Client, predicate func(T) bool) (T, error) {
+	w, err := NewEventWaiter(c, predicate)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return w.Wait(ctx)
+}
+
+// Function RequestAndWaitFor starts listening for an event of type T, then
+// sends a request, and blocks until a matching event arrives or ctx is
+// done. If the request fails, its error is returned without waiting.
+//
+//	evt, err := obs.RequestAndWaitFor(ctx, c, func() error {
+//		_, err := c.SetCurrentScene("Live")
+//		return err
+//	}, func(e *obs.TransitionEndEvent) bool {
+//		return e.ToScene == "Live"
+//	})
+func RequestAndWaitFor[T any](ctx context.Context, c *Client, request func() error, predicate func(T) bool) (T, error) {
+	var zero T
+	w, err := NewEventWaiter(c, predicate)
+	if err != nil {
+		return zero, err
+	}
+	if err = request(); err != nil {
+		w.Cancel()
+		return zero, err
+	}
+	return w.Wait(ctx)
+}
+
+// Function eventName returns the update type of the event type T.
+func eventName[T any]() (string, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Pointer || !strings.HasSuffix(t.Elem().Name(), "Event") {
+		return "", errors.New("not an event type: " + t.String())
+	}
+	name := strings.TrimSuffix(t.Elem().Name(), "Event")
+	if _, ok := eventConverters[name]; !ok {
+		return "", errors.New("not an event type: " + t.String())
+	}
+	return name, nil
+}
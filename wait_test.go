@@ -0,0 +1,37 @@
+package go_obs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	obs "github.com/woofdoggo/go-obs"
+)
+
+func TestEventWaiterDuringEvents(t *testing.T) {
+	f := newFakeOBS(t)
+	c := f.connect()
+
+	// Waiters created while events are arriving must not miss the removal
+	// of their listener.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				f.event("Heartbeat", map[string]any{"pulse": true})
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := obs.WaitFor[*obs.HeartbeatEvent](ctx, c, nil)
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}